@@ -0,0 +1,232 @@
+package tcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Command describes a command run by a check built with CommandCheck.
+type Command struct {
+	Name       string        // Program to run, looked up in PATH if it contains no path separator
+	Args       []string      // Arguments, without the program name
+	Env        []string      // Additional "KEY=value" entries on top of the current environment
+	Dir        string        // Working directory, the current one if empty
+	ExitCodes  []int         // Exit codes treated as success, {0} if empty
+	Stdout     OutputMatcher // Optional check of the complete standard output
+	Stderr     OutputMatcher // Optional check of the complete standard error
+	StderrTail int           // Number of stderr lines attached to the error, 10 if 0, none if negative
+}
+
+// OutputMatcher checks the output of a command and returns an error if it is
+// not as expected.
+type OutputMatcher func(output string) error
+
+// OutputContains returns an OutputMatcher requiring the output to contain substr.
+func OutputContains(substr string) OutputMatcher {
+	return func(output string) error {
+		if !strings.Contains(output, substr) {
+			return fmt.Errorf("output does not contain %q", substr)
+		}
+		return nil
+	}
+}
+
+// OutputMatches returns an OutputMatcher requiring the output to match the
+// regular expression pattern. It panics if pattern does not compile.
+func OutputMatches(pattern string) OutputMatcher {
+	re := regexp.MustCompile(pattern)
+	return func(output string) error {
+		if !re.MatchString(output) {
+			return fmt.Errorf("output does not match %q", pattern)
+		}
+		return nil
+	}
+}
+
+// CommandError is the error of a command check that did not succeed.
+type CommandError struct {
+	Command    string   // Command line that was run
+	ExitCode   int      // Exit code, -1 if the command did not exit normally
+	Err        error    // Why the check failed, e.g. an *exec.ExitError or the error of a matcher
+	StderrTail []string // Last lines of the standard error
+}
+
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Command, e.Err)
+	if len(e.StderrTail) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(e.StderrTail, " | "))
+	}
+	return msg
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// maxCapturedOutput limits how much output of a command is kept for the matchers.
+const maxCapturedOutput = 1 << 20
+
+// commandWaitDelay is how long the output of a command may stay open after
+// it exited or was killed, e.g. by child processes, before it is abandoned.
+const commandWaitDelay = time.Second
+
+// CommandCheck returns a check function running cmd. Output lines are logged
+// to the check's log, stdout at LogInfo and stderr at LogWarn, and the latest
+// stdout line is shown as progress message. The process is killed when the
+// check is cancelled or times out.
+// The check fails with a *CommandError if the command cannot be started,
+// exits with an unexpected code, or its output does not satisfy a matcher.
+func CommandCheck(cmd Command) CheckFuncContext {
+	return func(ctx context.Context, reporter SubProgressReporter) error {
+		return cmd.run(ctx, reporter)
+	}
+}
+
+// String returns the command line.
+func (cmd Command) String() string {
+	return strings.Join(append([]string{cmd.Name}, cmd.Args...), " ")
+}
+
+func (cmd Command) run(ctx context.Context, reporter SubProgressReporter) error {
+	log := LoggerOf(reporter)
+	c := exec.CommandContext(ctx, cmd.Name, cmd.Args...)
+	c.Dir = cmd.Dir
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+	c.WaitDelay = commandWaitDelay
+
+	stdout := &capturedOutput{onLine: func(line string) {
+		log.Infof("%s", line)
+		reporter.ReportSubProgress(0, line)
+	}}
+	stderr := &capturedOutput{tail: cmd.StderrTail, onLine: func(line string) {
+		log.Warnf("%s", line)
+	}}
+	if stderr.tail == 0 {
+		stderr.tail = 10
+	}
+	// Not pipes read by the check, so Wait returns once the command exited,
+	// within WaitDelay, even if a child process keeps the output open
+	c.Stdout = stdout
+	c.Stderr = stderr
+
+	reporter.ReportSubProgress(0, "Running "+cmd.Name)
+	log.Infof("running %s", cmd)
+	if err := c.Start(); err != nil {
+		return cmd.error(-1, err, nil)
+	}
+	err := c.Wait()
+	stdout.close()
+	stderr.close()
+
+	exitCode := -1
+	if c.ProcessState != nil {
+		exitCode = c.ProcessState.ExitCode()
+	}
+	exitCodes := cmd.ExitCodes
+	if len(exitCodes) == 0 {
+		exitCodes = []int{0}
+	}
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case exitCode == -1 || !slices.Contains(exitCodes, exitCode):
+		if err == nil || errors.Is(err, exec.ErrWaitDelay) {
+			err = fmt.Errorf("exit status %d", exitCode)
+		}
+		return cmd.error(exitCode, err, stderr.lines)
+	}
+	log.Infof("exited with code %d", exitCode)
+
+	if cmd.Stdout != nil {
+		if err := cmd.Stdout(stdout.String()); err != nil {
+			return cmd.error(exitCode, fmt.Errorf("stdout: %w", err), stderr.lines)
+		}
+	}
+	if cmd.Stderr != nil {
+		if err := cmd.Stderr(stderr.String()); err != nil {
+			return cmd.error(exitCode, fmt.Errorf("stderr: %w", err), stderr.lines)
+		}
+	}
+	return nil
+}
+
+func (cmd Command) error(exitCode int, err error, stderrTail []string) error {
+	return &CommandError{Command: cmd.String(), ExitCode: exitCode, Err: err, StderrTail: stderrTail}
+}
+
+// capturedOutput collects the output of a command, up to maxCapturedOutput
+// bytes, and keeps its last lines. It is written to by the command and calls
+// onLine for each line.
+type capturedOutput struct {
+	mu      sync.Mutex
+	output  strings.Builder
+	partial []byte // Last line while it is incomplete
+	onLine  func(line string)
+	tail    int      // Number of lines to keep in lines
+	lines   []string // Last lines of the output
+	closed  bool     // Whether close was called, later output is dropped
+}
+
+func (o *capturedOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed {
+		return len(p), nil // Output of a child process after the command was abandoned
+	}
+
+	if o.output.Len() < maxCapturedOutput {
+		o.output.Write(p)
+	}
+	o.partial = append(o.partial, p...)
+	for {
+		i := bytes.IndexByte(o.partial, '\n')
+		if i < 0 {
+			break
+		}
+		o.addLine(string(o.partial[:i]))
+		o.partial = o.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// close passes on the last line if it did not end with a newline, and drops
+// any output written later.
+func (o *capturedOutput) close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.partial) > 0 {
+		o.addLine(string(o.partial))
+		o.partial = nil
+	}
+	o.closed = true
+}
+
+// String returns the captured output. It must only be called after close.
+func (o *capturedOutput) String() string {
+	return o.output.String()
+}
+
+// addLine calls onLine for the line and keeps it. o.mu must be held.
+func (o *capturedOutput) addLine(line string) {
+	if line = strings.TrimRight(line, "\r"); line == "" {
+		return
+	}
+	o.onLine(line)
+	if o.tail > 0 {
+		o.lines = append(o.lines, line)
+		if len(o.lines) > o.tail {
+			o.lines = o.lines[1:]
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHelperProcess is not a real test, it is run as the command of the
+// command checks below.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("TCHECK_HELPER_PROCESS") != "1" {
+		return
+	}
+	switch os.Getenv("TCHECK_HELPER_MODE") {
+	case "output":
+		fmt.Println("hello")
+		fmt.Println("dir=" + mustGetwd())
+		fmt.Println("env=" + os.Getenv("TCHECK_EXTRA"))
+		fmt.Fprintln(os.Stderr, "note on stderr")
+		os.Exit(0)
+	case "fail":
+		for i := range 15 {
+			fmt.Fprintf(os.Stderr, "error line %d\n", i)
+		}
+		code, _ := strconv.Atoi(os.Getenv("TCHECK_EXIT_CODE"))
+		os.Exit(code)
+	case "hang":
+		fmt.Println("waiting")
+		time.Sleep(time.Minute)
+	case "background":
+		// Leave a child behind that keeps stdout open
+		child := exec.Command(os.Args[0], "-test.run=TestHelperProcess")
+		child.Env = append(os.Environ(), "TCHECK_HELPER_MODE=hang")
+		child.Stdout = os.Stdout
+		if err := child.Start(); err != nil {
+			panic(err)
+		}
+		fmt.Printf("child=%d\n", child.Process.Pid)
+		os.Exit(0)
+	}
+	os.Exit(0)
+}
+
+func mustGetwd() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	return dir
+}
+
+func helperCommand(mode string, env ...string) Command {
+	return Command{
+		Name: os.Args[0],
+		Args: []string{"-test.run=TestHelperProcess"},
+		Env:  append([]string{"TCHECK_HELPER_PROCESS=1", "TCHECK_HELPER_MODE=" + mode}, env...),
+	}
+}
+
+func TestCommandCheck_Success(t *testing.T) {
+	dir := t.TempDir()
+	cmd := helperCommand("output", "TCHECK_EXTRA=extra")
+	cmd.Dir = dir
+	cmd.Stdout = OutputMatches(`(?m)^hello$`)
+	cmd.Stderr = OutputContains("note")
+
+	item := NewCheckItemContext(1, "command", CommandCheck(cmd))
+	item.Run()
+
+	s := item.Snapshot()
+	if s.Status != StatusCompleted {
+		t.Fatalf("Expected StatusCompleted, got %v: %v", s.Status, s.Error)
+	}
+	var logged []string
+	for _, entry := range s.Logs {
+		logged = append(logged, entry.Level.String()+" "+entry.Message)
+	}
+	all := strings.Join(logged, "\n")
+	for _, expected := range []string{"INFO hello", "INFO dir=" + dir, "INFO env=extra", "WARN note on stderr"} {
+		if !strings.Contains(all, expected) {
+			t.Errorf("Expected %q in the log, got:\n%s", expected, all)
+		}
+	}
+}
+
+func TestCommandCheck_ExitCode(t *testing.T) {
+	item := NewCheckItemContext(1, "command", CommandCheck(helperCommand("fail", "TCHECK_EXIT_CODE=3")))
+	item.Run()
+
+	s := item.Snapshot()
+	var cmdErr *CommandError
+	if s.Status != StatusFailed || !errors.As(s.Error, &cmdErr) {
+		t.Fatalf("Expected a CommandError, got %v: %v", s.Status, s.Error)
+	}
+	if cmdErr.ExitCode != 3 {
+		t.Errorf("Expected exit code 3, got %d", cmdErr.ExitCode)
+	}
+	if len(cmdErr.StderrTail) != 10 || cmdErr.StderrTail[9] != "error line 14" {
+		t.Errorf("Expected the last 10 stderr lines, got %q", cmdErr.StderrTail)
+	}
+	if !strings.Contains(s.Error.Error(), "error line 14") {
+		t.Errorf("Expected the stderr tail in the error, got %v", s.Error)
+	}
+
+	// Expected exit codes are a success
+	cmd := helperCommand("fail", "TCHECK_EXIT_CODE=3")
+	cmd.ExitCodes = []int{0, 3}
+	item = NewCheckItemContext(2, "command", CommandCheck(cmd))
+	item.Run()
+	if s := item.Snapshot(); s.Status != StatusCompleted {
+		t.Errorf("Expected StatusCompleted, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestCommandCheck_Matcher(t *testing.T) {
+	cmd := helperCommand("output")
+	cmd.Stdout = OutputContains("goodbye")
+
+	item := NewCheckItemContext(1, "command", CommandCheck(cmd))
+	item.Run()
+
+	s := item.Snapshot()
+	var cmdErr *CommandError
+	if s.Status != StatusFailed || !errors.As(s.Error, &cmdErr) || !strings.Contains(s.Error.Error(), `stdout: output does not contain "goodbye"`) {
+		t.Errorf("Expected a matcher error, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestCommandCheck_NotFound(t *testing.T) {
+	item := NewCheckItemContext(1, "command", CommandCheck(Command{Name: "tcheck-no-such-command"}))
+	item.Run()
+
+	s := item.Snapshot()
+	var cmdErr *CommandError
+	if s.Status != StatusFailed || !errors.As(s.Error, &cmdErr) || cmdErr.ExitCode != -1 {
+		t.Errorf("Expected a CommandError, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestCommandCheck_Timeout(t *testing.T) {
+	item := NewCheckItemContext(1, "command", CommandCheck(helperCommand("hang")), WithTimeout(200*time.Millisecond))
+
+	start := time.Now()
+	item.RunContext(context.Background())
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the command to be killed on timeout, took %v", elapsed)
+	}
+	if s := item.Snapshot(); s.Status != StatusFailed || !errors.Is(s.Error, ErrTimeout) {
+		t.Errorf("Expected a timeout, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestCommandCheck_BackgroundChild(t *testing.T) {
+	cmd := helperCommand("background")
+	var childPid int
+	cmd.Stdout = func(output string) error {
+		_, pid, _ := strings.Cut(output, "child=")
+		_, err := fmt.Sscanf(pid, "%d", &childPid)
+		return err
+	}
+	item := NewCheckItemContext(1, "command", CommandCheck(cmd))
+
+	start := time.Now()
+	item.Run()
+	elapsed := time.Since(start)
+	if childPid > 0 {
+		if child, err := os.FindProcess(childPid); err == nil {
+			child.Kill()
+		}
+	}
+	if elapsed > commandWaitDelay+2*time.Second {
+		t.Errorf("Expected the check to return once the command exited, took %v", elapsed)
+	}
+	if s := item.Snapshot(); s.Status != StatusCompleted {
+		t.Errorf("Expected StatusCompleted, got %v: %v", s.Status, s.Error)
+	}
+}
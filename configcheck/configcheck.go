@@ -0,0 +1,175 @@
+// Package configcheck provides ready-made configuration file checks for
+// tcheck: JSON, YAML, TOML and .env files are parsed, syntax errors are
+// reported with their line and column, and the parsed values can be
+// validated against a simple Schema of required and optional keys.
+//
+//	manager.AddCheckContext("Config Syntax", configcheck.File("/etc/app/config.yaml", configcheck.Schema{
+//		Required: map[string]configcheck.Type{"server.port": configcheck.Integer},
+//	}))
+package configcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// Format is the format of a configuration file.
+type Format int
+
+const (
+	JSON Format = iota
+	YAML
+	TOML
+	Env // KEY=value lines, as read by shells and docker compose
+)
+
+func (f Format) String() string {
+	switch f {
+	case JSON:
+		return "JSON"
+	case YAML:
+		return "YAML"
+	case TOML:
+		return "TOML"
+	case Env:
+		return "env"
+	default:
+		return "unknown"
+	}
+}
+
+// FormatOf returns the format of path by its extension: .json, .yaml or .yml,
+// .toml, and .env or a name starting with ".env", such as ".env.local".
+func FormatOf(path string) (Format, error) {
+	base := filepath.Base(path)
+	switch strings.ToLower(filepath.Ext(base)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".toml":
+		return TOML, nil
+	case ".env":
+		return Env, nil
+	}
+	if strings.HasPrefix(base, ".env") {
+		return Env, nil
+	}
+	return 0, fmt.Errorf("unknown configuration format of %s", path)
+}
+
+// SyntaxError is the error of a configuration file that cannot be parsed.
+type SyntaxError struct {
+	File   string // Path of the file
+	Line   int    // Line of the error, starting at 1, or 0 if unknown
+	Column int    // Column of the error in bytes, starting at 1, or 0 if unknown
+	Msg    string // Description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+	case e.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	default:
+		return fmt.Sprintf("%s: %s", e.File, e.Msg)
+	}
+}
+
+// File returns a check that parses the configuration file at path, in the
+// format given by its extension, and validates it against schema.
+func File(path string, schema Schema) tcheck.CheckFuncContext {
+	format, err := FormatOf(path)
+	if err != nil {
+		return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+			return err
+		}
+	}
+	return check(path, format, schema)
+}
+
+// JSONFile returns a check that parses the JSON file at path and validates it
+// against schema.
+func JSONFile(path string, schema Schema) tcheck.CheckFuncContext {
+	return check(path, JSON, schema)
+}
+
+// YAMLFile returns a check that parses the first document of the YAML file at
+// path and validates it against schema. YAML errors carry no column.
+func YAMLFile(path string, schema Schema) tcheck.CheckFuncContext {
+	return check(path, YAML, schema)
+}
+
+// TOMLFile returns a check that parses the TOML file at path and validates it
+// against schema.
+func TOMLFile(path string, schema Schema) tcheck.CheckFuncContext {
+	return check(path, TOML, schema)
+}
+
+// EnvFile returns a check that parses the .env file at path and validates it
+// against schema. All values of .env files are strings; the schema types
+// check whether they can be converted, e.g. "8080" is an Integer.
+func EnvFile(path string, schema Schema) tcheck.CheckFuncContext {
+	return check(path, Env, schema)
+}
+
+func check(path string, format Format, schema Schema) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading "+path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		reporter.ReportSubProgress(30, fmt.Sprintf("Parsing %s as %s", path, format))
+		value, err := Parse(data, format)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if errors.As(err, &syntaxErr) {
+				syntaxErr.File = path
+			}
+			return err
+		}
+
+		reporter.ReportSubProgress(70, "Validating "+path)
+		if err := schema.validate(value, format == Env); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s is valid %s", path, format))
+		return nil
+	}
+}
+
+// Parse parses data in the given format. Objects are returned as
+// map[string]any, so they can be validated by a Schema. Syntax errors are
+// returned as *SyntaxError, without a file name.
+func Parse(data []byte, format Format) (any, error) {
+	switch format {
+	case JSON:
+		return parseJSON(data)
+	case YAML:
+		return parseYAML(data)
+	case TOML:
+		return parseTOML(data)
+	case Env:
+		return parseEnv(data)
+	default:
+		return nil, fmt.Errorf("unknown configuration format %d", format)
+	}
+}
+
+// position returns the 1-based line and column of the byte offset in data.
+func position(data []byte, offset int) (line, column int) {
+	offset = min(max(offset, 0), len(data))
+	before := data[:offset]
+	line = 1 + strings.Count(string(before), "\n")
+	column = offset - strings.LastIndexByte(string(before), '\n')
+	return line, column
+}
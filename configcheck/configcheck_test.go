@@ -0,0 +1,113 @@
+package configcheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]Format{
+		"config.json":     JSON,
+		"/etc/app/a.YAML": YAML,
+		"compose.yml":     YAML,
+		"Cargo.toml":      TOML,
+		"prod.env":        Env,
+		".env":            Env,
+		".env.local":      Env,
+	}
+	for path, expected := range tests {
+		if got, err := FormatOf(path); err != nil || got != expected {
+			t.Errorf("FormatOf(%q) = %v, %v, expected %v", path, got, err, expected)
+		}
+	}
+	if _, err := FormatOf("config.ini"); err == nil {
+		t.Error("Expected an error for an unknown extension")
+	}
+}
+
+func TestFile(t *testing.T) {
+	schema := Schema{Required: map[string]Type{"server.port": Integer}}
+
+	path := writeFile(t, "config.yaml", "server:\n  port: 8080\n")
+	s := tchecktest.Run(File(path, schema))
+	if s.Status != tcheck.StatusCompleted {
+		t.Fatalf("Expected StatusCompleted, got %v: %v", s.Status, s.Error)
+	}
+	if s.SubMessage != path+" is valid YAML" {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+
+	s = tchecktest.Run(File(writeFile(t, "config.ini", "[server]\n"), schema))
+	if s.Status != tcheck.StatusFailed || !strings.Contains(s.Error.Error(), "unknown configuration format") {
+		t.Errorf("Expected an unknown format, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestCheck_SyntaxError(t *testing.T) {
+	path := writeFile(t, "config.json", "{\n  \"server\": {\n    \"port\": 80,\n  }\n}\n")
+	s := tchecktest.Run(JSONFile(path, Schema{}))
+
+	var syntaxErr *SyntaxError
+	if s.Status != tcheck.StatusFailed || !errors.As(s.Error, &syntaxErr) {
+		t.Fatalf("Expected a SyntaxError, got %v: %v", s.Status, s.Error)
+	}
+	expected := path + ":4:3: invalid character '}' looking for beginning of object key string"
+	if s.Error.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, s.Error)
+	}
+}
+
+func TestCheck_SchemaError(t *testing.T) {
+	path := writeFile(t, ".env", "PORT=http\n")
+	s := tchecktest.Run(EnvFile(path, Schema{Required: map[string]Type{"PORT": Integer, "HOST": String}}))
+	expected := path + ": schema: missing HOST; PORT is string, expected integer"
+	if s.Status != tcheck.StatusFailed || s.Error.Error() != expected {
+		t.Errorf("Expected %q, got %v: %v", expected, s.Status, s.Error)
+	}
+}
+
+func TestCheck_Formats(t *testing.T) {
+	tests := []struct {
+		fn      func(string, Schema) tcheck.CheckFuncContext
+		content string
+		errText string
+	}{
+		{JSONFile, `{"a": 1}`, ""},
+		{YAMLFile, "a: 1\n", ""},
+		{TOMLFile, "a = 1\n", ""},
+		{EnvFile, "A=1\n", ""},
+		{YAMLFile, "a: 1\na: 2\n", ":2: mapping key"},
+		{TOMLFile, "a = \n", ":1:5: expected value"},
+		{EnvFile, "A=1\nB\n", ":2:1: expected KEY=value"},
+	}
+	for i, tt := range tests {
+		path := writeFile(t, "config", tt.content)
+		s := tchecktest.Run(tt.fn(path, Schema{}))
+		switch {
+		case tt.errText == "" && s.Status != tcheck.StatusCompleted:
+			t.Errorf("%d: expected StatusCompleted, got %v: %v", i, s.Status, s.Error)
+		case tt.errText != "" && (s.Error == nil || !strings.HasPrefix(s.Error.Error(), path+tt.errText)):
+			t.Errorf("%d: expected %q, got %v", i, path+tt.errText, s.Error)
+		}
+	}
+
+	s := tchecktest.Run(JSONFile(filepath.Join(t.TempDir(), "missing.json"), Schema{}))
+	if s.Status != tcheck.StatusFailed || !errors.Is(s.Error, os.ErrNotExist) {
+		t.Errorf("Expected a missing file, got %v: %v", s.Status, s.Error)
+	}
+}
@@ -0,0 +1,154 @@
+package configcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+func parseJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep integers exact
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		var syntaxErr *json.SyntaxError
+		switch {
+		case errors.As(err, &syntaxErr):
+			// The offset is just after the offending byte
+			return nil, jsonError(data, int(syntaxErr.Offset)-1, syntaxErr.Error())
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			return nil, jsonError(data, len(data), "unexpected end of JSON input")
+		default:
+			return nil, err
+		}
+	}
+	offset := int(dec.InputOffset())
+	if _, err := dec.Token(); err != io.EOF {
+		offset += len(data[offset:]) - len(bytes.TrimLeft(data[offset:], " \t\r\n"))
+		return nil, jsonError(data, offset, "invalid data after top-level value")
+	}
+	return value, nil
+}
+
+func jsonError(data []byte, offset int, msg string) *SyntaxError {
+	line, column := position(data, offset)
+	return &SyntaxError{Line: line, Column: column, Msg: msg}
+}
+
+// yamlLine matches the line number in errors of the YAML parser.
+var yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+func parseYAML(data []byte) (any, error) {
+	var value any
+	err := yaml.Unmarshal(data, &value)
+	if err == nil {
+		return value, nil
+	}
+	msg := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0] // The first of e.g. several duplicate keys
+	}
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return nil, &SyntaxError{Line: line, Msg: msg[len(m[0]):]}
+	}
+	return nil, &SyntaxError{Msg: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+func parseTOML(data []byte) (any, error) {
+	var value map[string]any
+	if _, err := toml.Decode(string(data), &value); err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			// Line and Col of the position are off for errors at a newline
+			line, column := position(data, parseErr.Position.Start)
+			return nil, &SyntaxError{Line: line, Column: column, Msg: parseErr.Message}
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+// envKey matches valid keys of .env files.
+var envKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// parseEnv parses KEY=value lines. Lines may start with "export", values may
+// be quoted with single quotes, taken literally, or double quotes, which
+// support backslash escapes. Unquoted values end at a " #" comment.
+func parseEnv(data []byte) (any, error) {
+	values := make(map[string]any)
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		column := func(rest string) int { return len(line) - len(rest) + 1 }
+		if rest, ok := strings.CutPrefix(trimmed, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			trimmed = strings.TrimLeft(rest, " \t")
+		}
+
+		key, rawValue, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			return nil, &SyntaxError{Line: lineNo, Column: column(trimmed), Msg: "expected KEY=value"}
+		}
+		key = strings.TrimRight(key, " \t")
+		if !envKey.MatchString(key) {
+			return nil, &SyntaxError{Line: lineNo, Column: column(trimmed), Msg: fmt.Sprintf("invalid key %q", key)}
+		}
+		rawValue = strings.TrimLeft(rawValue, " \t")
+		value, err := parseEnvValue(rawValue)
+		if err != nil {
+			return nil, &SyntaxError{Line: lineNo, Column: column(rawValue), Msg: err.Error()}
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func parseEnvValue(raw string) (string, error) {
+	if raw == "" || (raw[0] != '"' && raw[0] != '\'') {
+		if i := strings.Index(raw, " #"); i >= 0 {
+			raw = raw[:i]
+		}
+		return strings.TrimRight(raw, " \t"), nil
+	}
+
+	quote := raw[0]
+	var b strings.Builder
+	for i := 1; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == quote:
+			if rest := strings.TrimLeft(raw[i+1:], " \t"); rest != "" && rest[0] != '#' {
+				return "", fmt.Errorf("unexpected %q after quoted value", rest)
+			}
+			return b.String(), nil
+		case c == '\\' && quote == '"' && i+1 < len(raw):
+			i++
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(raw[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated %c quote", quote)
+}
@@ -0,0 +1,139 @@
+package configcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse_Valid(t *testing.T) {
+	tests := []struct {
+		format Format
+		data   string
+	}{
+		{JSON, `{"name": "app", "server": {"port": 8080, "tls": true}, "ratio": 0.5, "tags": ["a", "b"]}`},
+		{YAML, "name: app\nserver:\n  port: 8080\n  tls: true\nratio: 0.5\ntags:\n  - a\n  - b\n"},
+		{TOML, "name = \"app\"\nratio = 0.5\ntags = [\"a\", \"b\"]\n\n[server]\nport = 8080\ntls = true\n"},
+	}
+	for _, tt := range tests {
+		value, err := Parse([]byte(tt.data), tt.format)
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", tt.format, err)
+			continue
+		}
+		port, ok := lookup(value, "server.port")
+		if !ok || typeOf(port) != Integer {
+			t.Errorf("%v: expected an integer server.port, got %#v", tt.format, port)
+		}
+		if tags, _ := lookup(value, "tags"); typeOf(tags) != Array {
+			t.Errorf("%v: expected an array of tags, got %#v", tt.format, tags)
+		}
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+		line   int
+		column int
+		msg    string
+	}{
+		{"json invalid character", JSON, "{\n  \"a\": 1,\n  \"b\": x\n}", 3, 8, "invalid character 'x'"},
+		{"json missing comma", JSON, "{\n  \"a\": 1\n  \"b\": 2\n}", 3, 3, "after object key:value pair"},
+		{"json truncated", JSON, "{\n  \"a\": [1, 2", 2, 13, "unexpected end of JSON input"},
+		{"json empty", JSON, "", 1, 1, "unexpected end of JSON input"},
+		{"json trailing data", JSON, "{\"a\": 1}\n\n  {\"b\": 2}", 3, 3, "invalid data after top-level value"},
+		{"yaml missing colon", YAML, "a: 1\nb: 2\nc 2\nd: 3\n", 3, 0, "could not find expected ':'"},
+		{"yaml duplicate key", YAML, "a: 1\nb: 2\na: 3\n", 3, 0, "mapping key \"a\" already defined at line 1"},
+		{"yaml bad indentation", YAML, "a:\n  b: 1\n c: 2\n", 2, 0, "did not find expected key"},
+		{"toml missing value", TOML, "a = 1\nb = \n", 2, 5, "expected value"},
+		{"toml duplicate key", TOML, "a = 1\na = 2\n", 2, 1, "already been defined"},
+		{"toml unclosed table", TOML, "a = 1\n[server\nport = 1\n", 2, 8, "to end table name"},
+		{"env missing equals", Env, "A=1\n  B\n", 2, 3, "expected KEY=value"},
+		{"env invalid key", Env, "A=1\n1B=2\n", 2, 1, `invalid key "1B"`},
+		{"env unterminated quote", Env, "A=1\nexport B = \"abc\n", 2, 12, "unterminated \" quote"},
+		{"env text after quote", Env, "A='x' y\n", 1, 3, "after quoted value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data), tt.format)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected a SyntaxError, got %v", err)
+			}
+			if syntaxErr.Line != tt.line || (tt.column != 0 && syntaxErr.Column != tt.column) {
+				t.Errorf("Expected %d:%d, got %d:%d (%s)", tt.line, tt.column, syntaxErr.Line, syntaxErr.Column, syntaxErr.Msg)
+			}
+			if !strings.Contains(syntaxErr.Msg, tt.msg) {
+				t.Errorf("Expected %q in the message, got %q", tt.msg, syntaxErr.Msg)
+			}
+		})
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	data := `# Database
+DB_HOST=localhost
+DB_PORT = 5432 # inline comment
+export DB_USER=app
+DB_PASSWORD='p#ss "word"'
+GREETING="Hello,\n\"World\"" # comment
+EMPTY=
+URL=http://example.com/#anchor
+spring.profiles.active=dev
+`
+	value, err := Parse([]byte(data), Env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"DB_HOST":                "localhost",
+		"DB_PORT":                "5432",
+		"DB_USER":                "app",
+		"DB_PASSWORD":            `p#ss "word"`,
+		"GREETING":               "Hello,\n\"World\"",
+		"EMPTY":                  "",
+		"URL":                    "http://example.com/#anchor",
+		"spring.profiles.active": "dev",
+	}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("Expected %q, got %q", expected, value)
+	}
+}
+
+func TestParseJSON_Numbers(t *testing.T) {
+	value, err := Parse([]byte(`{"big": 9007199254740993, "float": 1.0}`), JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := value.(map[string]any)
+	if obj["big"] != json.Number("9007199254740993") {
+		t.Errorf("Expected the exact integer, got %v", obj["big"])
+	}
+	if typeOf(obj["float"]) != Number {
+		t.Errorf("Expected 1.0 to be a number, got %v", typeOf(obj["float"]))
+	}
+}
+
+func TestPosition(t *testing.T) {
+	data := []byte("ab\ncd\n\nef")
+	tests := []struct {
+		offset, line, column int
+	}{
+		{0, 1, 1},
+		{1, 1, 2},
+		{3, 2, 1},
+		{7, 4, 1},
+		{9, 4, 3},
+		{100, 4, 3},
+	}
+	for _, tt := range tests {
+		if line, column := position(data, tt.offset); line != tt.line || column != tt.column {
+			t.Errorf("position(%d) = %d:%d, expected %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package configcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the expected type of a configuration value.
+type Type int
+
+const (
+	Any     Type = iota // Any value
+	String              // String
+	Number              // Integer or floating-point number
+	Integer             // Integer
+	Bool                // Boolean
+	Object              // Object, mapping or table
+	Array               // Array or sequence
+)
+
+func (t Type) String() string {
+	switch t {
+	case String:
+		return "string"
+	case Number:
+		return "number"
+	case Integer:
+		return "integer"
+	case Bool:
+		return "bool"
+	case Object:
+		return "object"
+	case Array:
+		return "array"
+	default:
+		return "any"
+	}
+}
+
+// Schema describes the keys of a configuration file. Keys are dotted paths
+// into nested objects, e.g. "server.port". The zero value accepts any file.
+type Schema struct {
+	Required map[string]Type // Keys that must be present, with their type
+	Optional map[string]Type // Keys that may be missing, but must have their type if present
+}
+
+// validate checks value against the schema, returning an error listing every
+// violation. Values of .env files are strings that must convert to the type.
+func (s Schema) validate(value any, fromEnv bool) error {
+	var problems []string
+	check := func(keys map[string]Type, required bool) {
+		for _, key := range sortedKeys(keys) {
+			v, ok := lookup(value, key)
+			switch {
+			case !ok && required:
+				problems = append(problems, fmt.Sprintf("missing %s", key))
+			case ok && !hasType(v, keys[key], fromEnv):
+				problems = append(problems, fmt.Sprintf("%s is %s, expected %s", key, typeName(v), keys[key]))
+			}
+		}
+	}
+	check(s.Required, true)
+	check(s.Optional, false)
+	if len(problems) > 0 {
+		return fmt.Errorf("schema: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]Type) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookup returns the value at the dotted key in nested objects. Keys
+// containing dots themselves, as is common in .env files, match as a whole.
+func lookup(value any, key string) (any, bool) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if v, ok := obj[key]; ok {
+		return v, true
+	}
+	for i := strings.IndexByte(key, '.'); i >= 0; i = nextDot(key, i) {
+		if child, ok := obj[key[:i]]; ok {
+			if v, ok := lookup(child, key[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// nextDot returns the index of the next dot in key after i, or -1.
+func nextDot(key string, i int) int {
+	j := strings.IndexByte(key[i+1:], '.')
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
+
+// hasType reports whether v is of type t.
+func hasType(v any, t Type, fromEnv bool) bool {
+	if s, ok := v.(string); ok && fromEnv {
+		switch t {
+		case Number:
+			_, err := strconv.ParseFloat(s, 64)
+			return err == nil
+		case Integer:
+			_, err := strconv.ParseInt(s, 10, 64)
+			return err == nil
+		case Bool:
+			_, err := strconv.ParseBool(s)
+			return err == nil
+		case Object, Array:
+			return false
+		default:
+			return true
+		}
+	}
+	if t == Any {
+		return true
+	}
+	return typeOf(v) == t || (t == Number && typeOf(v) == Integer)
+}
+
+// typeOf returns the type of a parsed value, or Any for other values, such
+// as null or TOML dates.
+func typeOf(v any) Type {
+	switch v := v.(type) {
+	case string:
+		return String
+	case bool:
+		return Bool
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return Integer
+		}
+		return Number
+	case int, int64, uint64:
+		return Integer
+	case float64: // Written as a float, e.g. 1.0 in YAML and TOML
+		return Number
+	case map[string]any, map[any]any:
+		return Object
+	case []any, []map[string]any:
+		return Array
+	default:
+		return Any
+	}
+}
+
+// typeName describes the type of a parsed value for errors.
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case time.Time:
+		return "date"
+	}
+	if t := typeOf(v); t != Any {
+		return t.String()
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,103 @@
+package configcheck
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	configs := map[Format]string{
+		JSON: `{"name": "app", "debug": false, "server": {"port": 8080, "timeout": 2.5}, "tags": ["a"], "owner": null}`,
+		YAML: "name: app\ndebug: false\nserver:\n  port: 8080\n  timeout: 2.5\ntags: [a]\nowner: null\n",
+		TOML: "name = \"app\"\ndebug = false\ntags = [\"a\"]\n[server]\nport = 8080\ntimeout = 2.5\n",
+	}
+	valid := Schema{
+		Required: map[string]Type{
+			"name":           String,
+			"debug":          Bool,
+			"server":         Object,
+			"server.port":    Integer,
+			"server.timeout": Number,
+			"tags":           Array,
+		},
+		Optional: map[string]Type{
+			"server.host": String,
+			"server.port": Number, // Integers are numbers
+			"missing":     Object,
+		},
+	}
+	invalid := Schema{
+		Required: map[string]Type{
+			"name":           Integer,
+			"server.timeout": Integer,
+			"server.host":    String,
+		},
+		Optional: map[string]Type{
+			"tags": Object,
+		},
+	}
+
+	for format, config := range configs {
+		value, err := Parse([]byte(config), format)
+		if err != nil {
+			t.Fatalf("%v: %v", format, err)
+		}
+		if err := valid.validate(value, false); err != nil {
+			t.Errorf("%v: unexpected error %v", format, err)
+		}
+		err = invalid.validate(value, false)
+		expected := "schema: name is string, expected integer; missing server.host; server.timeout is number, expected integer; tags is array, expected object"
+		if err == nil || err.Error() != expected {
+			t.Errorf("%v: expected %q, got %v", format, expected, err)
+		}
+	}
+}
+
+func TestSchema_Env(t *testing.T) {
+	value, err := Parse([]byte("PORT=8080\nRATIO=0.5\nDEBUG=true\nNAME=app\nlog.level=info\n"), Env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := Schema{Required: map[string]Type{
+		"PORT":      Integer,
+		"RATIO":     Number,
+		"DEBUG":     Bool,
+		"NAME":      String,
+		"log.level": String,
+	}}
+	if err := valid.validate(value, true); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+
+	invalid := Schema{Required: map[string]Type{"RATIO": Integer, "NAME": Bool}}
+	err = invalid.validate(value, true)
+	if err == nil || !strings.Contains(err.Error(), "NAME is string, expected bool; RATIO is string, expected integer") {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	value := map[string]any{
+		"a":     map[string]any{"b": map[string]any{"c": 1}},
+		"x.y":   2,
+		"x":     map[string]any{"z": 3},
+		"plain": "value",
+	}
+	tests := []struct {
+		key   string
+		value any
+		found bool
+	}{
+		{"a.b.c", 1, true},
+		{"x.y", 2, true},
+		{"x.z", 3, true},
+		{"a.b.d", nil, false},
+		{"plain.sub", nil, false},
+	}
+	for _, tt := range tests {
+		v, ok := lookup(value, tt.key)
+		if ok != tt.found || (ok && v != tt.value) {
+			t.Errorf("lookup(%q) = %v, %v, expected %v, %v", tt.key, v, ok, tt.value, tt.found)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package tcheck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrDependencyCycle is returned when adding a check would create a dependency cycle.
+	ErrDependencyCycle = errors.New("dependency cycle")
+	// ErrDependencyFailed is wrapped by the error of a check skipped because
+	// one of its dependencies did not complete successfully.
+	ErrDependencyFailed = errors.New("dependency not satisfied")
+)
+
+// dependency refers to another check, either by ID or by name.
+type dependency struct {
+	id   int
+	name string
+}
+
+func (d dependency) String() string {
+	if d.name != "" {
+		return fmt.Sprintf("%q", d.name)
+	}
+	return fmt.Sprintf("#%d", d.id)
+}
+
+// DependsOn makes the check run only after every check with one of the given
+// names has completed successfully. Names may refer to checks added later,
+// also while a run is in progress: the check waits for them as long as other
+// checks of the run are still running or about to start, and is skipped once
+// nothing is left that could add them.
+func DependsOn(names ...string) CheckOption {
+	return func(ci *CheckItem) {
+		for _, name := range names {
+			ci.deps = append(ci.deps, dependency{name: name})
+		}
+	}
+}
+
+// DependsOnID makes the check run only after the checks with the given IDs
+// have completed successfully.
+func DependsOnID(ids ...int) CheckOption {
+	return func(ci *CheckItem) {
+		for _, id := range ids {
+			ci.deps = append(ci.deps, dependency{id: id})
+		}
+	}
+}
+
+// resolve returns the items among items that the dependency refers to.
+func (d dependency) resolve(items []*CheckItem) []*CheckItem {
+	var resolved []*CheckItem
+	for _, item := range items {
+		if (d.name != "" && item.Name == d.name) || (d.name == "" && item.ID == d.id) {
+			resolved = append(resolved, item)
+		}
+	}
+	return resolved
+}
+
+// findCycle returns the dependency path leading from start back to start,
+// or nil if there is none. Dependencies that cannot be resolved are ignored.
+func findCycle(start *CheckItem, items []*CheckItem) []*CheckItem {
+	visited := make(map[*CheckItem]bool)
+	var path []*CheckItem
+
+	var visit func(item *CheckItem) bool
+	visit = func(item *CheckItem) bool {
+		path = append(path, item)
+		for _, dep := range item.deps {
+			for _, next := range dep.resolve(items) {
+				if next == start {
+					path = append(path, next)
+					return true
+				}
+				if !visited[next] {
+					visited[next] = true
+					if visit(next) {
+						return true
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if visit(start) {
+		return path
+	}
+	return nil
+}
+
+// cycleError describes a dependency cycle found by findCycle.
+func cycleError(cycle []*CheckItem) error {
+	names := make([]string, len(cycle))
+	for i, item := range cycle {
+		names[i] = fmt.Sprintf("%q", item.Name)
+	}
+	return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(names, " -> "))
+}
+
+// dependencyState reports whether the item's dependencies are satisfied.
+// It returns ready when all of them completed, and a non-nil error when one
+// of them can no longer complete. scheduled counts the executions of items
+// in the current run that have not finished yet; other items are not waited for.
+// Unknown dependencies are waited for if waitUnknown is set, as they may
+// still be added to the run.
+func dependencyState(item *CheckItem, items []*CheckItem, scheduled map[*CheckItem]int, waitUnknown bool) (ready bool, err error) {
+	ready = true
+	for _, dep := range item.deps {
+		resolved := dep.resolve(items)
+		if len(resolved) == 0 {
+			if waitUnknown {
+				ready = false
+				continue
+			}
+			return false, fmt.Errorf("%w: unknown check %s", ErrDependencyFailed, dep)
+		}
+		for _, depItem := range resolved {
+			depItem.mu.Lock()
+			status := depItem.Status
+			depItem.mu.Unlock()
+
+			switch {
+			case status == StatusCompleted, status == StatusWarning:
+			case status.isDone():
+				return false, fmt.Errorf("%w: %q %s", ErrDependencyFailed, depItem.Name, status)
+			case scheduled[depItem] > 0:
+				ready = false
+			default:
+				return false, fmt.Errorf("%w: %q is not scheduled", ErrDependencyFailed, depItem.Name)
+			}
+		}
+	}
+	return ready, nil
+}
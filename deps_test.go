@@ -0,0 +1,127 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAddCheck_DependencyCycle(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	if _, err := cm.AddCheck("a", testFunc, DependsOn("b")); err != nil {
+		t.Fatalf("unexpected error for forward reference: %v", err)
+	}
+	if _, err := cm.AddCheck("b", testFunc, DependsOn("a")); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle, got %v", err)
+	}
+	if _, err := cm.AddCheck("self", testFunc, DependsOn("self")); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle for self dependency, got %v", err)
+	}
+	if len(cm.items) != 1 {
+		t.Errorf("expected checks with cycles not to be added, got %d items", len(cm.items))
+	}
+}
+
+func TestRunAllChecks_DependencyOrder(t *testing.T) {
+	cm := NewCheckManager(nil, 3)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) CheckFunc {
+		return func(SubProgressReporter) error {
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	cm.AddCheck("schema", record("schema"), DependsOn("connection"))
+	connID, _ := cm.AddCheck("connection", record("connection"))
+	cm.AddCheck("migrations", record("migrations"), DependsOnID(connID), DependsOn("schema"))
+
+	cm.RunAllChecks()
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{"connection", "schema", "migrations"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestRunAllChecks_DependencyFailedSkips(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+
+	cm.AddCheck("connection", func(SubProgressReporter) error { return errors.New("refused") })
+	cm.AddCheck("schema", testFunc, DependsOn("connection"))
+	cm.AddCheck("data", testFunc, DependsOn("schema"))
+	cm.AddCheck("orphan", testFunc, DependsOn("missing"))
+
+	cm.RunAllChecks()
+	time.Sleep(50 * time.Millisecond)
+
+	for _, item := range cm.GetItems()[1:] {
+		item.mu.Lock()
+		if item.Status != StatusSkipped || !errors.Is(item.Error, ErrDependencyFailed) {
+			t.Errorf("expected %q to be skipped, got %v (%v)", item.Name, item.Status, item.Error)
+		}
+		item.mu.Unlock()
+	}
+}
+
+func TestRunAllChecks_LastDependencyFailed(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	cm.AddCheck("fail", func(SubProgressReporter) error { return errors.New("broken") })
+	cm.AddCheck("dependent", testFunc, DependsOn("fail"))
+
+	run := cm.RunAllChecks()
+	select {
+	case <-run.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not finish after its last checks were skipped")
+	}
+}
+
+func TestStart_DependencyAddedDuringRun(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+
+	release := make(chan struct{})
+	cm.AddCheck("running", func(SubProgressReporter) error {
+		<-release
+		return nil
+	})
+	cm.AddCheck("dependent", testFunc, DependsOn("later"))
+	cm.AddCheck("orphan", testFunc, DependsOn("missing"))
+
+	run := cm.Start(context.Background())
+	time.Sleep(10 * time.Millisecond)
+	cm.AddCheck("later", testFunc)
+	close(release)
+
+	select {
+	case <-run.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not finish")
+	}
+	statuses := make(map[string]CheckStatus)
+	for _, s := range snapshotAll(run.Items()) {
+		statuses[s.Name] = s.Status
+	}
+	if statuses["dependent"] != StatusCompleted || statuses["later"] != StatusCompleted {
+		t.Errorf("Expected the dependency added during the run to be waited for, got %v", statuses)
+	}
+	if statuses["orphan"] != StatusSkipped {
+		t.Errorf("Expected a check with an unknown dependency to be skipped once the run drained, got %v", statuses["orphan"])
+	}
+}
@@ -0,0 +1,202 @@
+package tcheck
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EventType identifies what changed in an Event.
+type EventType int
+
+const (
+	EventRunStarted    EventType = iota // A run started
+	EventRunFinished                    // A run finished, Summary is set
+	EventCheckStarted                   // A check started running
+	EventCheckProgress                  // The sub-progress of a running check changed
+	EventCheckMessage                   // The sub-message of a running check changed
+	EventCheckFinished                  // A check reached a final status, including checks that never started
+	EventCheckLog                       // A running check logged a line, Log is set
+)
+
+// String returns a human-readable name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventRunStarted:
+		return "run started"
+	case EventRunFinished:
+		return "run finished"
+	case EventCheckStarted:
+		return "check started"
+	case EventCheckProgress:
+		return "check progress"
+	case EventCheckMessage:
+		return "check message"
+	case EventCheckFinished:
+		return "check finished"
+	case EventCheckLog:
+		return "check log"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// Event describes a state transition of a run or a check. The check fields
+// hold the state of the check at the time of the event, and are zero for run
+// events.
+type Event struct {
+	Type     EventType
+	Time     time.Time
+	Run      *RunHandle // Run the event belongs to, nil for checks run outside of a run
+	Check    *CheckItem // Check the event belongs to, nil for run events
+	ID       int
+	Name     string
+	Status   CheckStatus
+	Progress int
+	Message  string
+	Attempt  int
+	Err      error
+	Duration time.Duration // Time the check or run took, for EventCheckFinished and EventRunFinished
+	Summary  *Summary      // Summary of the run, for EventRunFinished
+	Log      *LogEntry     // The logged line, for EventCheckLog
+}
+
+// subscriber delivers events to a callback in order, from its own goroutine,
+// so slow subscribers never block the checks.
+type subscriber struct {
+	fn       func(Event)
+	stopped  func()     // Called once delivery has stopped, may be nil
+	mu       sync.Mutex // Guards queue
+	queue    []Event
+	wake     chan struct{} // Notifies the delivering goroutine about queued events
+	stop     chan struct{} // Closed on unsubscribe
+	stopOnce sync.Once
+}
+
+// Subscribe calls fn for every event published by the manager, in the order
+// they were published, until the returned function is called.
+// fn is called from a separate goroutine and never concurrently with itself;
+// events published while fn is busy are queued.
+func (cm *CheckManager) Subscribe(fn func(Event)) (unsubscribe func()) {
+	s := newSubscriber()
+	s.fn = fn
+	return cm.addSubscriber(s)
+}
+
+// SubscribeChan is like Subscribe, but delivers the events on the returned
+// channel, which is closed once unsubscribe is called.
+func (cm *CheckManager) SubscribeChan() (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event)
+	s := newSubscriber()
+	s.fn = func(e Event) {
+		select {
+		case ch <- e:
+		case <-s.stop:
+		}
+	}
+	s.stopped = func() { close(ch) }
+	return ch, cm.addSubscriber(s)
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+	}
+}
+
+// addSubscriber registers s and starts delivering events to it.
+func (cm *CheckManager) addSubscriber(s *subscriber) (unsubscribe func()) {
+	cm.subscribersMu.Lock()
+	cm.subscribers = append(cm.subscribers, s)
+	cm.subscribersMu.Unlock()
+
+	go s.deliver()
+	return func() {
+		cm.subscribersMu.Lock()
+		cm.subscribers = slices.DeleteFunc(cm.subscribers, func(other *subscriber) bool { return other == s })
+		cm.subscribersMu.Unlock()
+		s.stopOnce.Do(func() { close(s.stop) })
+	}
+}
+
+// deliver passes queued events to the callback until the subscriber is stopped.
+func (s *subscriber) deliver() {
+	if s.stopped != nil {
+		defer s.stopped()
+	}
+	for {
+		s.mu.Lock()
+		events := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, e := range events {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+			s.fn(e)
+		}
+
+		select {
+		case <-s.wake:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// publish queues the event for all subscribers and marks the manager dirty.
+func (cm *CheckManager) publish(e Event) {
+	defer cm.markDirty()
+
+	cm.subscribersMu.Lock()
+	defer cm.subscribersMu.Unlock()
+	for _, s := range cm.subscribers {
+		s.mu.Lock()
+		s.queue = append(s.queue, e)
+		s.mu.Unlock()
+		select {
+		case s.wake <- struct{}{}:
+		default: // The subscriber is already notified
+		}
+	}
+}
+
+// emit publishes an event with the current state of the item, if it belongs
+// to a manager.
+func (ci *CheckItem) emit(typ EventType, duration time.Duration) {
+	ci.publish(Event{Type: typ, Duration: duration})
+}
+
+// emitLog publishes an EventCheckLog for entry.
+func (ci *CheckItem) emitLog(entry LogEntry) {
+	ci.publish(Event{Type: EventCheckLog, Log: &entry})
+}
+
+// publish completes e with the current state of the item and publishes it,
+// if the item belongs to a manager.
+func (ci *CheckItem) publish(e Event) {
+	s := ci.Snapshot()
+	ci.mu.Lock()
+	run, cm := ci.run, ci.manager
+	ci.mu.Unlock()
+
+	e.Time = time.Now()
+	e.Run = run
+	e.Check = ci
+	e.ID = s.ID
+	e.Name = s.Name
+	e.Status = s.Status
+	e.Progress = s.SubProgress
+	e.Message = s.SubMessage
+	e.Attempt = s.Attempt
+	e.Err = s.Error
+
+	if cm != nil {
+		cm.publish(e)
+	}
+}
@@ -0,0 +1,117 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubscribeChan(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	events, unsubscribe := cm.SubscribeChan()
+	defer unsubscribe()
+
+	expectedErr := errors.New("boom")
+	cm.AddCheck("progress", func(r SubProgressReporter) error {
+		r.ReportSubProgress(50, "halfway")
+		return nil
+	})
+	cm.AddCheck("fail", func(SubProgressReporter) error { return expectedErr })
+	cm.AddCheck("dependent", testFunc, DependsOn("fail"))
+	run := cm.Start(context.Background())
+
+	var types []EventType
+	finished := make(map[string]Event)
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case e := <-events:
+			types = append(types, e.Type)
+			if e.Run != run {
+				t.Errorf("Expected event %v to belong to the run", e.Type)
+			}
+			switch e.Type {
+			case EventCheckProgress:
+				if e.Name != "progress" || e.Progress != 50 {
+					t.Errorf("Unexpected progress event %+v", e)
+				}
+			case EventCheckMessage:
+				if e.Message != "halfway" {
+					t.Errorf("Unexpected message event %+v", e)
+				}
+			case EventCheckFinished:
+				finished[e.Name] = e
+			case EventRunFinished:
+				if e.Summary == nil || e.Summary.Total != 3 {
+					t.Errorf("Expected the summary of the run, got %+v", e.Summary)
+				}
+				done = true
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for events, got %v", types)
+		}
+	}
+
+	expected := []EventType{
+		EventRunStarted,
+		EventCheckStarted, EventCheckProgress, EventCheckMessage, EventCheckFinished,
+		EventCheckStarted, EventCheckFinished,
+		EventCheckFinished,
+		EventRunFinished,
+	}
+	if len(types) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, types)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Fatalf("Expected events %v, got %v", expected, types)
+		}
+	}
+
+	if e := finished["progress"]; e.Status != StatusCompleted || e.Duration <= 0 {
+		t.Errorf("Unexpected finished event %+v", e)
+	}
+	if e := finished["fail"]; e.Status != StatusFailed || !errors.Is(e.Err, expectedErr) {
+		t.Errorf("Unexpected finished event %+v", e)
+	}
+	if e := finished["dependent"]; e.Status != StatusSkipped || !errors.Is(e.Err, ErrDependencyFailed) {
+		t.Errorf("Unexpected finished event %+v", e)
+	}
+}
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	received := make(chan Event, 100)
+	unsubscribe := cm.Subscribe(func(e Event) { received <- e })
+	events, unsubscribeChan := cm.SubscribeChan()
+
+	cm.AddCheck("check", testFunc)
+	cm.RunAllChecks()
+	for e := range events {
+		if e.Type == EventRunFinished {
+			break
+		}
+	}
+	unsubscribeChan()
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed after unsubscribe")
+	}
+
+	// Callbacks run in order, so the run is finished once its last event arrives
+	for e := range received {
+		if e.Type == EventRunFinished {
+			break
+		}
+	}
+	unsubscribe()
+	unsubscribe() // Calling it twice is fine
+
+	cm.RerunAll().Wait()
+	select {
+	case e := <-received:
+		t.Errorf("Expected no events after unsubscribe, got %v", e.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
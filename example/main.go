@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
 	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/syscheck"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -22,16 +24,11 @@ func main() {
 	}
 
 	// Create CheckManager and UIRenderer
-	// The UIRenderer needs a way to be told to redraw when item states change.
-	// We'll use a simple function reference for this.
-	var ui *tcheck.UIRenderer
-	manager := tcheck.NewCheckManager(func() {
-		if ui != nil {
-			ui.Draw() // Trigger a redraw
-		}
-	}, 3) // Allow up to 3 checks to run concurrently
+	// The UIRenderer redraws by itself whenever the state of the checks changes,
+	// so no update callback is needed.
+	manager := tcheck.NewCheckManager(nil, 3) // Allow up to 3 checks to run concurrently
 
-	ui = tcheck.NewUIRenderer(s, manager)
+	ui := tcheck.NewUIRenderer(s, manager)
 
 	// --- How to Add Custom Check Functions ---
 	manager.AddCheck("Checking Network Connectivity", func(reporter tcheck.SubProgressReporter) error {
@@ -48,14 +45,7 @@ func main() {
 
 	manager.AddCheck("Verifying File Permissions", ExampleCheckSuccessful) // Using a predefined function
 	manager.AddCheck("Checking Database Connection", ExampleCheckFailed)
-	manager.AddCheck("System Resource Check", func(reporter tcheck.SubProgressReporter) error {
-		reporter.ReportSubProgress(10, "Checking CPU...")
-		time.Sleep(300 * time.Millisecond)
-		reporter.ReportSubProgress(50, "Checking Memory...")
-		time.Sleep(500 * time.Millisecond)
-		reporter.ReportSubProgress(100, "Resources OK")
-		return nil
-	})
+	manager.AddCheckContext("System Resource Check", syscheck.MemAvailable(syscheck.Limit{Fail: 256 << 20, Warn: 1 << 30}))
 	manager.AddCheck("External API Availability", ExampleCheckLongNoSubProgress)
 	manager.AddCheck("Configuration File Syntax", ExampleCheckQuick)
 	manager.AddCheck("Disk Space Check", func(reporter tcheck.SubProgressReporter) error {
@@ -67,11 +57,11 @@ func main() {
 		return nil
 	})
 	manager.AddCheck("Another Successful Check", ExampleCheckSuccessful)
-	manager.AddCheck("Yet Another Failing Check", ExampleCheckFailed)
+	manager.AddCheck("Yet Another Failing Check", ExampleCheckFailed, tcheck.WithSeverity(tcheck.SeverityOptional))
 	manager.AddCheck("Quick Pass", ExampleCheckQuick)
 
 	// Start running checks in the background
-	go manager.RunAllChecks()
+	run := manager.Start(context.Background())
 
 	// Start the UI event loop (this will block until quit)
 	ui.Run()
@@ -81,22 +71,22 @@ func main() {
 	time.Sleep(100 * time.Millisecond) // Sleep for a bit to allow the UI to finish drawing.
 	s.Fini()
 
-	// Collect failed checks
-	failed := []string{}
-	for _, item := range manager.GetItems() {
-		if item.Status == tcheck.StatusFailed {
-			// Collect the information of failed checks
-			failed = append(failed, fmt.Sprintf("%s: %v", item.Name, item.Error))
+	// Wait for the run to finish and report failed checks
+	summary := run.Wait()
+	for _, fail := range summary.Failures {
+		fmt.Printf("❌ %s: %v\n", fail.Name, fail.Err)
+		for _, entry := range fail.Logs {
+			fmt.Printf("    %s\n", entry)
 		}
 	}
+	for _, warning := range summary.Warnings {
+		fmt.Printf("⚠ %s: %v\n", warning.Name, warning.Err)
+	}
 
-	if len(failed) > 0 {
-		// If any check failed, do something...
-		fmt.Println("❌ Exiting due to failed checks:")
-		for _, fail := range failed {
-			fmt.Printf(" - %s\n", fail)
-		}
-		os.Exit(1)
+	// Exit consistently according to the severities of the checks
+	if verdict := manager.Verdict(); verdict == tcheck.VerdictFail {
+		fmt.Println("Exiting due to failed checks.")
+		os.Exit(verdict.ExitCode())
 	}
 
 	// If all checks passed, continue with the next steps
@@ -124,10 +114,14 @@ func ExampleCheckSuccessful(reporter tcheck.SubProgressReporter) error {
 
 // ExampleCheckFailed demonstrates a check that fails.
 func ExampleCheckFailed(reporter tcheck.SubProgressReporter) error {
+	log := tcheck.LoggerOf(reporter)
 	reporter.ReportSubProgress(0, "Attempting critical operation...")
+	log.Infof("requesting resource")
 	time.Sleep(1 * time.Second)
 	reporter.ReportSubProgress(50, "Operation in progress...")
+	log.Warnf("resource busy, waiting")
 	time.Sleep(1 * time.Second)
+	log.Errorf("gave up waiting for resource")
 	return fmt.Errorf("simulated failure: resource not available")
 }
 
@@ -0,0 +1,136 @@
+package fscheck
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// ManifestEntry is a file listed in a checksum manifest.
+type ManifestEntry struct {
+	Name   string // File name as listed, relative to the manifest's directory unless absolute
+	SHA256 string // Expected checksum, lowercase hex
+}
+
+// ParseManifest parses a manifest in the format of sha256sum: one
+// "<checksum>  <name>" line per file, with " *" instead of two spaces for
+// binary mode. Empty lines and lines starting with '#' are ignored.
+func ParseManifest(r io.Reader) ([]ManifestEntry, error) {
+	var entries []ManifestEntry
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// Names with a backslash or newline are escaped, marked by a leading backslash
+		escaped := strings.HasPrefix(line, `\`)
+		if escaped {
+			line = line[1:]
+		}
+		sum, name, ok := strings.Cut(line, " ")
+		if !ok || len(sum) != sha256.Size*2 || len(name) < 2 || (name[0] != ' ' && name[0] != '*') {
+			return nil, fmt.Errorf("line %d: invalid manifest line %q", lineNo, scanner.Text())
+		}
+		if _, err := hex.DecodeString(sum); err != nil {
+			return nil, fmt.Errorf("line %d: invalid checksum: %w", lineNo, err)
+		}
+		name = name[1:]
+		if escaped {
+			name = strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(name)
+		}
+		entries = append(entries, ManifestEntry{Name: name, SHA256: strings.ToLower(sum)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// VerifyChecksums returns a check that verifies the files listed in the
+// sha256sum-style manifest file, reporting progress per file. Relative names
+// are resolved against the directory of the manifest. All files are verified,
+// and the check fails listing every missing or mismatching one.
+func VerifyChecksums(manifest string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		log := tcheck.LoggerOf(reporter)
+		reporter.ReportSubProgress(0, "Reading "+manifest)
+		f, err := os.Open(manifest)
+		if err != nil {
+			return err
+		}
+		entries, err := ParseManifest(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", manifest, err)
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("%s lists no files", manifest)
+		}
+
+		dir := filepath.Dir(manifest)
+		var failed []string
+		for i, entry := range entries {
+			reporter.ReportSubProgress(i*100/len(entries), fmt.Sprintf("Verifying %s (%d/%d)", entry.Name, i+1, len(entries)))
+			path := entry.Name
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			sum, err := fileSHA256(ctx, path)
+			switch {
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case err != nil:
+				log.Errorf("%v", err)
+				failed = append(failed, err.Error())
+			case sum != entry.SHA256:
+				log.Errorf("%s: checksum mismatch, expected %s, got %s", entry.Name, entry.SHA256, sum)
+				failed = append(failed, entry.Name+": checksum mismatch")
+			default:
+				log.Debugf("%s: OK", entry.Name)
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("%d of %d files failed verification: %s", len(failed), len(entries), strings.Join(failed, "; "))
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("Verified %d files", len(entries)))
+		return nil
+	}
+}
+
+// fileSHA256 returns the hex SHA-256 checksum of the file, stopping early
+// when ctx is done.
+func fileSHA256(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, contextReader{ctx, f}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// contextReader is a reader failing once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,140 @@
+package fscheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+// progressRecorder is a SubProgressReporter recording every report.
+type progressRecorder struct {
+	reports []string
+}
+
+func (r *progressRecorder) ReportSubProgress(percentage int, message string) {
+	r.reports = append(r.reports, fmt.Sprintf("%d%% %s", percentage, message))
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParseManifest(t *testing.T) {
+	sum := sha256Hex("a")
+	manifest := "# comment\n" +
+		sum + "  a.txt\n" +
+		"\n" +
+		strings.ToUpper(sum) + " *bin/b.dat\r\n" +
+		`\` + sum + `  dir\\c\nd` + "\n"
+	entries, err := ParseManifest(strings.NewReader(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ManifestEntry{
+		{Name: "a.txt", SHA256: sum},
+		{Name: "bin/b.dat", SHA256: sum},
+		{Name: "dir\\c\nd", SHA256: sum},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected %q, got %q", expected, entries)
+	}
+
+	for _, invalid := range []string{
+		"abc  a.txt",
+		sum + " a.txt",
+		sum + "  ",
+		strings.Repeat("zz", sha256.Size) + "  a.txt",
+	} {
+		_, err := ParseManifest(strings.NewReader("# header\n" + invalid))
+		if err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+			t.Errorf("Expected an error on line 2 for %q, got %v", invalid, err)
+		}
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "alpha")
+	writeFile(t, filepath.Join(dir, "b.txt"), "beta")
+	manifest := filepath.Join(dir, "SHA256SUMS")
+	writeFile(t, manifest, sha256Hex("alpha")+"  a.txt\n"+sha256Hex("beta")+"  b.txt\n")
+
+	recorder := &progressRecorder{}
+	if err := VerifyChecksums(manifest)(context.Background(), recorder); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"0% Reading " + manifest,
+		"0% Verifying a.txt (1/2)",
+		"50% Verifying b.txt (2/2)",
+		"100% Verified 2 files",
+	}
+	if !reflect.DeepEqual(recorder.reports, expected) {
+		t.Errorf("Expected progress %q, got %q", expected, recorder.reports)
+	}
+}
+
+func TestVerifyChecksums_Failures(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "alpha")
+	writeFile(t, filepath.Join(dir, "b.txt"), "corrupted")
+	manifest := filepath.Join(dir, "SHA256SUMS")
+	writeFile(t, manifest, sha256Hex("alpha")+"  a.txt\n"+
+		sha256Hex("beta")+"  b.txt\n"+
+		sha256Hex("gamma")+"  c.txt\n")
+
+	s := tchecktest.Run(VerifyChecksums(manifest))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	msg := s.Error.Error()
+	if !strings.HasPrefix(msg, "2 of 3 files failed verification: ") ||
+		!strings.Contains(msg, "b.txt: checksum mismatch") || !strings.Contains(msg, "c.txt") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+
+	var errorLogs int
+	for _, entry := range s.Logs {
+		if entry.Level == tcheck.LogError {
+			errorLogs++
+		}
+	}
+	if errorLogs != 2 {
+		t.Errorf("Expected 2 error log lines, got %v", s.Logs)
+	}
+}
+
+func TestVerifyChecksums_Cancel(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.txt"), "alpha")
+	manifest := filepath.Join(dir, "SHA256SUMS")
+	writeFile(t, manifest, sha256Hex("alpha")+"  a.txt\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := VerifyChecksums(manifest)(ctx, &progressRecorder{})
+	if err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestVerifyChecksums_InvalidManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "SHA256SUMS")
+	writeFile(t, manifest, "# nothing\n")
+	tchecktest.ExpectStatus(t, tchecktest.Run(VerifyChecksums(manifest)), tcheck.StatusFailed)
+
+	writeFile(t, manifest, "not a manifest\n")
+	s := tchecktest.Run(VerifyChecksums(manifest))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "line 1") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+}
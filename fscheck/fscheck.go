@@ -0,0 +1,219 @@
+// Package fscheck provides ready-made filesystem checks for tcheck: path
+// types, ownership and modes, writable directories, free space, file sizes
+// and checksum manifests.
+//
+// The constructors return context-aware check functions, to be added with
+// CheckManager.AddCheckContext.
+//
+//	manager.AddCheckContext("Data Directory", fscheck.WritableDir("/var/lib/app"))
+package fscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"strconv"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// PathType is the expected type of a path checked by PathExists.
+type PathType int
+
+const (
+	AnyType     PathType = iota // Any type of file
+	RegularFile                 // Regular file, following symlinks
+	Directory                   // Directory, following symlinks
+	Symlink                     // Symbolic link itself
+)
+
+func (t PathType) String() string {
+	switch t {
+	case RegularFile:
+		return "regular file"
+	case Directory:
+		return "directory"
+	case Symlink:
+		return "symlink"
+	default:
+		return "file"
+	}
+}
+
+// matches reports whether a file of the given mode is of type t.
+func (t PathType) matches(mode fs.FileMode) bool {
+	switch t {
+	case RegularFile:
+		return mode.IsRegular()
+	case Directory:
+		return mode.IsDir()
+	case Symlink:
+		return mode&fs.ModeSymlink != 0
+	default:
+		return true
+	}
+}
+
+// PathExists returns a check that path exists and is of the given type.
+func PathExists(path string, typ PathType) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking "+path)
+		stat := os.Stat
+		if typ == Symlink {
+			stat = os.Lstat
+		}
+		info, err := stat(path)
+		if err != nil {
+			return err
+		}
+		if !typ.matches(info.Mode()) {
+			return fmt.Errorf("%s is not a %s (mode %s)", path, typ, info.Mode())
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s is a %s", path, typ))
+		return nil
+	}
+}
+
+// FileMode returns a check that the permission bits of path are exactly mode,
+// e.g. 0o600.
+func FileMode(path string, mode fs.FileMode) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking mode of "+path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if got := info.Mode().Perm(); got != mode.Perm() {
+			return fmt.Errorf("%s has mode %#o, expected %#o", path, got, mode.Perm())
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s has mode %#o", path, mode.Perm()))
+		return nil
+	}
+}
+
+// FileOwner returns a check that path is owned by owner and group, given as
+// names or numeric IDs. An empty owner or group matches any.
+// It is not supported on platforms without Unix file ownership.
+func FileOwner(path, owner, group string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking owner of "+path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		uid, gid, ok := fileOwner(info)
+		if !ok {
+			return fmt.Errorf("file ownership: %w", errors.ErrUnsupported)
+		}
+		if owner != "" {
+			want, err := lookupID(owner, user.Lookup, func(u *user.User) string { return u.Uid })
+			if err != nil {
+				return err
+			}
+			if want != uid {
+				return fmt.Errorf("%s is owned by user %d, expected %s", path, uid, owner)
+			}
+		}
+		if group != "" {
+			want, err := lookupID(group, user.LookupGroup, func(g *user.Group) string { return g.Gid })
+			if err != nil {
+				return err
+			}
+			if want != gid {
+				return fmt.Errorf("%s is owned by group %d, expected %s", path, gid, group)
+			}
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s is owned by %d:%d", path, uid, gid))
+		return nil
+	}
+}
+
+// lookupID resolves a user or group name to its numeric ID. Numeric names are
+// taken as IDs.
+func lookupID[T any](name string, lookup func(string) (T, error), id func(T) string) (uint32, error) {
+	if n, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(n), nil
+	}
+	entry, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(id(entry), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected ID of %s: %w", name, err)
+	}
+	return uint32(n), nil
+}
+
+// WritableDir returns a check that path is a directory in which files can be
+// created, by creating and removing a temporary file.
+func WritableDir(path string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking "+path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+
+		reporter.ReportSubProgress(50, "Writing to "+path)
+		f, err := os.CreateTemp(path, ".tcheck-*")
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", path, err)
+		}
+		defer os.Remove(f.Name())
+		_, err = f.WriteString("tcheck")
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("%s is not writable: %w", path, err)
+		}
+
+		reporter.ReportSubProgress(100, path+" is writable")
+		return nil
+	}
+}
+
+// FileSize returns a check that path is a regular file of at least minSize
+// and at most maxSize bytes. A maxSize of 0 means no upper limit.
+func FileSize(path string, minSize, maxSize int64) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking size of "+path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", path)
+		}
+		size := info.Size()
+		switch {
+		case size < minSize:
+			return fmt.Errorf("%s has %s, less than %s", path, formatBytes(uint64(size)), formatBytes(uint64(minSize)))
+		case maxSize > 0 && size > maxSize:
+			return fmt.Errorf("%s has %s, more than %s", path, formatBytes(uint64(size)), formatBytes(uint64(maxSize)))
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s has %s", path, formatBytes(uint64(size))))
+		return nil
+	}
+}
+
+// formatBytes formats n bytes with a binary unit, e.g. "1.5 GiB".
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
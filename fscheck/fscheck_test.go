@@ -0,0 +1,148 @@
+package fscheck
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPathExists(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	link := filepath.Join(dir, "link")
+	writeFile(t, file, "data")
+	if err := os.Symlink(file, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+
+	tests := []struct {
+		path   string
+		typ    PathType
+		status tcheck.CheckStatus
+	}{
+		{file, AnyType, tcheck.StatusCompleted},
+		{file, RegularFile, tcheck.StatusCompleted},
+		{file, Directory, tcheck.StatusFailed},
+		{dir, Directory, tcheck.StatusCompleted},
+		{dir, RegularFile, tcheck.StatusFailed},
+		{link, Symlink, tcheck.StatusCompleted},
+		{link, RegularFile, tcheck.StatusCompleted},
+		{file, Symlink, tcheck.StatusFailed},
+		{filepath.Join(dir, "missing"), AnyType, tcheck.StatusFailed},
+	}
+	for _, tt := range tests {
+		s := tchecktest.Run(PathExists(tt.path, tt.typ))
+		if s.Status != tt.status {
+			t.Errorf("%s as %v: expected %v, got %v: %v", filepath.Base(tt.path), tt.typ, tt.status, s.Status, s.Error)
+		}
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret")
+	writeFile(t, file, "data")
+	if err := os.Chmod(file, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileMode(file, 0o600)), tcheck.StatusCompleted)
+	s := tchecktest.Run(FileMode(file, 0o644))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "has mode 0600, expected 0644") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+}
+
+func TestFileOwner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file ownership not supported")
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	writeFile(t, file, "data")
+	uid, gid := strconv.Itoa(os.Getuid()), strconv.Itoa(os.Getgid())
+
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileOwner(file, uid, gid)), tcheck.StatusCompleted)
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileOwner(file, "", "")), tcheck.StatusCompleted)
+	if u, err := user.LookupId(uid); err == nil {
+		tchecktest.ExpectStatus(t, tchecktest.Run(FileOwner(file, u.Username, "")), tcheck.StatusCompleted)
+	}
+
+	other := strconv.Itoa(os.Getuid() + 1)
+	s := tchecktest.Run(FileOwner(file, other, ""))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "expected "+other) {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileOwner(file, "", strconv.Itoa(os.Getgid()+1))), tcheck.StatusFailed)
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileOwner(file, "no-such-user-tcheck", "")), tcheck.StatusFailed)
+}
+
+func TestWritableDir(t *testing.T) {
+	dir := t.TempDir()
+	tchecktest.ExpectStatus(t, tchecktest.Run(WritableDir(dir)), tcheck.StatusCompleted)
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected the temporary file to be removed, got %v", entries)
+	}
+
+	file := filepath.Join(dir, "file")
+	writeFile(t, file, "data")
+	tchecktest.ExpectStatus(t, tchecktest.Run(WritableDir(file)), tcheck.StatusFailed)
+
+	if runtime.GOOS != "windows" && os.Getuid() != 0 {
+		readOnly := filepath.Join(dir, "readonly")
+		if err := os.Mkdir(readOnly, 0o555); err != nil {
+			t.Fatal(err)
+		}
+		s := tchecktest.Run(WritableDir(readOnly))
+		tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+		if !strings.Contains(s.Error.Error(), "is not writable") {
+			t.Errorf("Unexpected error %v", s.Error)
+		}
+	}
+}
+
+func TestFileSize(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	writeFile(t, file, strings.Repeat("x", 2048))
+
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileSize(file, 1, 0)), tcheck.StatusCompleted)
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileSize(file, 2048, 2048)), tcheck.StatusCompleted)
+	s := tchecktest.Run(FileSize(file, 0, 1024))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "has 2.0 KiB, more than 1.0 KiB") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileSize(file, 4096, 0)), tcheck.StatusFailed)
+	tchecktest.ExpectStatus(t, tchecktest.Run(FileSize(dir, 0, 0)), tcheck.StatusFailed)
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[uint64]string{
+		0:       "0 B",
+		1023:    "1023 B",
+		1024:    "1.0 KiB",
+		1536:    "1.5 KiB",
+		5 << 30: "5.0 GiB",
+		3 << 40: "3.0 TiB",
+	}
+	for n, expected := range tests {
+		if got := formatBytes(n); got != expected {
+			t.Errorf("formatBytes(%d) = %q, expected %q", n, got, expected)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package fscheck
+
+import "io/fs"
+
+// fileOwner is not supported on this platform.
+func fileOwner(info fs.FileInfo) (uid, gid uint32, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,57 @@
+package fscheck
+
+import (
+	"context"
+	"fmt"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// SpaceOptions are the thresholds of FreeSpace. Zero thresholds are not checked.
+type SpaceOptions struct {
+	MinFree        uint64 // Fail if fewer bytes are available
+	WarnFree       uint64 // Warn if fewer bytes are available
+	MinFreeInodes  uint64 // Fail if fewer inodes are free
+	WarnFreeInodes uint64 // Warn if fewer inodes are free
+}
+
+// diskUsage is the free space of a filesystem.
+type diskUsage struct {
+	Free       uint64 // Bytes available to unprivileged users
+	Total      uint64 // Size of the filesystem in bytes
+	FreeInodes uint64 // Free inodes
+	Inodes     uint64 // Total inodes, 0 if the filesystem has no inode limit
+}
+
+// FreeSpace returns a check of the free space and inodes of the filesystem
+// containing path. It fails below the Min thresholds and warns below the
+// Warn thresholds of opts.
+// It is only supported on Linux, macOS and FreeBSD.
+func FreeSpace(path string, opts SpaceOptions) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Checking free space of "+path)
+		usage, err := statDisk(path)
+		if err != nil {
+			return fmt.Errorf("free space of %s: %w", path, err)
+		}
+		tcheck.LoggerOf(reporter).Infof("%s: %s of %s free, %d of %d inodes free",
+			path, formatBytes(usage.Free), formatBytes(usage.Total), usage.FreeInodes, usage.Inodes)
+
+		space := fmt.Sprintf("%s has %s free", path, formatBytes(usage.Free))
+		inodes := fmt.Sprintf("%s has %d inodes free", path, usage.FreeInodes)
+		hasInodes := usage.Inodes > 0
+		switch {
+		case usage.Free < opts.MinFree:
+			return fmt.Errorf("%s, less than %s", space, formatBytes(opts.MinFree))
+		case hasInodes && usage.FreeInodes < opts.MinFreeInodes:
+			return fmt.Errorf("%s, less than %d", inodes, opts.MinFreeInodes)
+		case usage.Free < opts.WarnFree:
+			return tcheck.Warnf("%s, less than %s", space, formatBytes(opts.WarnFree))
+		case hasInodes && usage.FreeInodes < opts.WarnFreeInodes:
+			return tcheck.Warnf("%s, less than %d", inodes, opts.WarnFreeInodes)
+		}
+
+		reporter.ReportSubProgress(100, space)
+		return nil
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd)
+
+package fscheck
+
+import "errors"
+
+// statDisk is not supported on this platform.
+func statDisk(path string) (diskUsage, error) {
+	return diskUsage{}, errors.ErrUnsupported
+}
@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd
+
+package fscheck
+
+import "syscall"
+
+// statDisk returns the usage of the filesystem containing path.
+func statDisk(path string) (diskUsage, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return diskUsage{}, err
+	}
+	return diskUsage{
+		Free:       uint64(st.Bavail) * uint64(st.Bsize),
+		Total:      uint64(st.Blocks) * uint64(st.Bsize),
+		FreeInodes: uint64(st.Ffree),
+		Inodes:     uint64(st.Files),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package fscheck
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func TestFreeSpace(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := statDisk(dir); errors.Is(err, errors.ErrUnsupported) {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   SpaceOptions
+		status tcheck.CheckStatus
+	}{
+		{"no thresholds", SpaceOptions{}, tcheck.StatusCompleted},
+		{"enough", SpaceOptions{MinFree: 1, WarnFree: 1}, tcheck.StatusCompleted},
+		{"too little", SpaceOptions{MinFree: math.MaxUint64}, tcheck.StatusFailed},
+		{"little", SpaceOptions{MinFree: 1, WarnFree: math.MaxUint64}, tcheck.StatusWarning},
+		{"failure first", SpaceOptions{MinFree: math.MaxUint64, WarnFree: math.MaxUint64}, tcheck.StatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tchecktest.ExpectStatus(t, tchecktest.Run(FreeSpace(dir, tt.opts)), tt.status)
+		})
+	}
+
+	// Filesystems without an inode limit pass any inode threshold
+	usage, _ := statDisk(dir)
+	status := tcheck.StatusFailed
+	if usage.Inodes == 0 {
+		status = tcheck.StatusCompleted
+	}
+	tchecktest.ExpectStatus(t, tchecktest.Run(FreeSpace(dir, SpaceOptions{MinFreeInodes: math.MaxUint64})), status)
+}
+
+func TestFreeSpace_Missing(t *testing.T) {
+	tchecktest.ExpectStatus(t, tchecktest.Run(FreeSpace("/no/such/path/tcheck", SpaceOptions{})), tcheck.StatusFailed)
+}
@@ -2,7 +2,11 @@ module github.com/Golevka2001/go-tcheck
 
 go 1.22
 
-require github.com/gdamore/tcell/v2 v2.8.1
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/gdamore/tcell/v2 v2.8.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/gdamore/encoding v1.0.1 // indirect
@@ -0,0 +1,135 @@
+package tcheck
+
+// CheckGroup is a named section of checks, e.g. "Network" or "Storage".
+// Groups can be nested; a group's status and progress roll up all checks
+// in the group and its subgroups.
+type CheckGroup struct {
+	ID      int
+	Name    string
+	Parent  *CheckGroup // nil for top-level groups
+	manager *CheckManager
+}
+
+// InGroup adds the check to the given group.
+func InGroup(group *CheckGroup) CheckOption {
+	return func(ci *CheckItem) {
+		ci.Group = group
+	}
+}
+
+// AddGroup adds a new group to the manager. Pass a parent group to nest it,
+// or nil to add a top-level group.
+func (cm *CheckManager) AddGroup(name string, parent *CheckGroup) *CheckGroup {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.groupCounter++
+	group := &CheckGroup{
+		ID:      cm.groupCounter,
+		Name:    name,
+		Parent:  parent,
+		manager: cm,
+	}
+	cm.groups = append(cm.groups, group)
+	return group
+}
+
+// GetGroups returns a thread-safe copy of the groups, in the order they were added.
+func (cm *CheckManager) GetGroups() []*CheckGroup {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	groupsCopy := make([]*CheckGroup, len(cm.groups))
+	copy(groupsCopy, cm.groups)
+	return groupsCopy
+}
+
+// Contains reports whether the item belongs to the group or one of its subgroups.
+func (g *CheckGroup) Contains(item *CheckItem) bool {
+	return g.containsGroup(item.Group)
+}
+
+// containsGroup reports whether group is g or one of its subgroups.
+func (g *CheckGroup) containsGroup(group *CheckGroup) bool {
+	for parent := group; parent != nil; parent = parent.Parent {
+		if parent == g {
+			return true
+		}
+	}
+	return false
+}
+
+// Depth returns how deeply the group is nested, 0 for top-level groups.
+func (g *CheckGroup) Depth() int {
+	depth := 0
+	for parent := g.Parent; parent != nil; parent = parent.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Items returns the checks of the group and its subgroups.
+func (g *CheckGroup) Items() []*CheckItem {
+	var items []*CheckItem
+	for _, item := range g.manager.GetItems() {
+		if g.Contains(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Status rolls up the statuses of the group's checks: a group is pending until
+// one of its checks starts, in progress until all of them are done, and then
+// failed, aborted, cancelled, warning or completed, in that order of
+// precedence. A group whose checks were all skipped is skipped.
+func (g *CheckGroup) Status() CheckStatus {
+	return rollUpStatus(g.snapshots(g.manager.Snapshot()))
+}
+
+// Progress calculates the progress of the group's checks, like
+// CheckManager.CalculateOverallProgress.
+func (g *CheckGroup) Progress() (int, int, int) {
+	return progressOf(g.snapshots(g.manager.Snapshot()))
+}
+
+// snapshots returns the snapshots of the group's checks among all.
+func (g *CheckGroup) snapshots(all []CheckSnapshot) []CheckSnapshot {
+	var snapshots []CheckSnapshot
+	for _, s := range all {
+		if g.containsGroup(s.Group) {
+			snapshots = append(snapshots, s)
+		}
+	}
+	return snapshots
+}
+
+// rollUpStatus combines the statuses of items into a single status.
+func rollUpStatus(items []CheckSnapshot) CheckStatus {
+	counts := make(map[CheckStatus]int)
+	for _, item := range items {
+		counts[item.Status]++
+	}
+
+	switch {
+	case len(items) == 0:
+		return StatusPending
+	case counts[StatusInProgress] > 0:
+		return StatusInProgress
+	case counts[StatusPending] == len(items):
+		return StatusPending
+	case counts[StatusPending] > 0:
+		return StatusInProgress
+	case counts[StatusFailed] > 0:
+		return StatusFailed
+	case counts[StatusAborted] > 0:
+		return StatusAborted
+	case counts[StatusCancelled] > 0:
+		return StatusCancelled
+	case counts[StatusWarning] > 0:
+		return StatusWarning
+	case counts[StatusSkipped] == len(items):
+		return StatusSkipped
+	default:
+		return StatusCompleted
+	}
+}
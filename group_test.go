@@ -0,0 +1,94 @@
+package tcheck
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGroupStatusRollUp(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	network := cm.AddGroup("Network", nil)
+	ipv6 := cm.AddGroup("IPv6", network)
+
+	cm.AddCheck("gateway", testFunc, InGroup(network))
+	cm.AddCheck("dns", testFunc, InGroup(ipv6))
+
+	if status := network.Status(); status != StatusPending {
+		t.Errorf("Expected pending group, got %v", status)
+	}
+
+	items := cm.GetItems()
+	items[0].mu.Lock()
+	items[0].Status = StatusCompleted
+	items[0].mu.Unlock()
+
+	if status := network.Status(); status != StatusInProgress {
+		t.Errorf("Expected in-progress group while a check is pending, got %v", status)
+	}
+	if completed, total, percentage := network.Progress(); completed != 1 || total != 2 || percentage != 50 {
+		t.Errorf("Expected (1,2,50), got (%d,%d,%d)", completed, total, percentage)
+	}
+
+	items[1].mu.Lock()
+	items[1].Status = StatusWarning
+	items[1].mu.Unlock()
+
+	if status := network.Status(); status != StatusWarning {
+		t.Errorf("Expected warning group when a nested check warned, got %v", status)
+	}
+
+	items[1].mu.Lock()
+	items[1].Status = StatusFailed
+	items[1].Error = errors.New("no route")
+	items[1].mu.Unlock()
+
+	if status := network.Status(); status != StatusFailed {
+		t.Errorf("Expected failed group when a nested check failed, got %v", status)
+	}
+	if status := ipv6.Status(); status != StatusFailed {
+		t.Errorf("Expected failed subgroup, got %v", status)
+	}
+	if ipv6.Depth() != 1 || !network.Contains(items[1]) || ipv6.Contains(items[0]) {
+		t.Error("Unexpected group nesting")
+	}
+}
+
+func TestLayoutRows(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	network := cm.AddGroup("Network", nil)
+	ipv6 := cm.AddGroup("IPv6", network)
+	cm.AddGroup("Empty", nil)
+
+	cm.AddCheck("first", testFunc)
+	cm.AddCheck("dns", testFunc, InGroup(ipv6))
+	cm.AddCheck("last", testFunc)
+	cm.AddCheck("gateway", testFunc, InGroup(network))
+
+	rows := layoutRows(cm.Snapshot())
+
+	expected := []struct {
+		depth int
+		name  string
+	}{
+		{0, "first"},
+		{0, "Network"},
+		{1, "IPv6"},
+		{2, "dns"},
+		{1, "gateway"},
+		{0, "last"},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(rows))
+	}
+	for i, r := range rows {
+		name := ""
+		if r.group != nil {
+			name = r.group.Name
+		} else {
+			name = r.item.Name
+		}
+		if r.depth != expected[i].depth || name != expected[i].name {
+			t.Errorf("Row %d: expected %q at depth %d, got %q at depth %d", i, expected[i].name, expected[i].depth, name, r.depth)
+		}
+	}
+}
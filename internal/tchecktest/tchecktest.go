@@ -0,0 +1,36 @@
+// Package tchecktest provides helpers shared by the tests of the check
+// packages, like netcheck and fscheck, to run a check function without
+// setting up a CheckManager.
+package tchecktest
+
+import (
+	"strings"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// Run runs fn as a check item with the given options and returns its final snapshot.
+func Run(fn tcheck.CheckFuncContext, opts ...tcheck.CheckOption) tcheck.CheckSnapshot {
+	item := tcheck.NewCheckItemContext(1, "check", fn, opts...)
+	item.Run()
+	return item.Snapshot()
+}
+
+// ExpectStatus stops the test if s does not have the given status.
+func ExpectStatus(t testing.TB, s tcheck.CheckSnapshot, status tcheck.CheckStatus) {
+	t.Helper()
+	if s.Status != status {
+		t.Fatalf("Expected %v, got %v: %v", status, s.Status, s.Error)
+	}
+}
+
+// ExpectResult is like ExpectStatus, and also fails the test if the error of s
+// does not contain errText. An empty errText matches any error.
+func ExpectResult(t testing.TB, s tcheck.CheckSnapshot, status tcheck.CheckStatus, errText string) {
+	t.Helper()
+	ExpectStatus(t, s, status)
+	if errText != "" && (s.Error == nil || !strings.Contains(s.Error.Error(), errText)) {
+		t.Errorf("Expected %q in the error, got %v", errText, s.Error)
+	}
+}
@@ -0,0 +1,37 @@
+package tchecktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+func TestRun(t *testing.T) {
+	s := Run(func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(100, "done")
+		return nil
+	})
+	ExpectStatus(t, s, tcheck.StatusCompleted)
+	if s.SubMessage != "done" {
+		t.Errorf("Expected the reported message, got %q", s.SubMessage)
+	}
+
+	s = Run(func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		return errors.New("broken")
+	})
+	ExpectResult(t, s, tcheck.StatusFailed, "broken")
+}
+
+func TestRun_Options(t *testing.T) {
+	s := Run(func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, tcheck.WithTimeout(10*time.Millisecond))
+	ExpectStatus(t, s, tcheck.StatusFailed)
+	if !errors.Is(s.Error, tcheck.ErrTimeout) {
+		t.Errorf("Expected a timeout, got %v", s.Error)
+	}
+}
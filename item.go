@@ -1,6 +1,9 @@
 package tcheck
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -13,8 +16,90 @@ const (
 	StatusInProgress
 	StatusCompleted
 	StatusFailed
+	StatusCancelled
+	StatusSkipped
+	StatusAborted
+	StatusWarning // Completed, but found a non-fatal problem
 )
 
+// String returns a human-readable name of the status.
+func (s CheckStatus) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusInProgress:
+		return "in progress"
+	case StatusCompleted:
+		return "completed"
+	case StatusFailed:
+		return "failed"
+	case StatusCancelled:
+		return "cancelled"
+	case StatusSkipped:
+		return "skipped"
+	case StatusAborted:
+		return "aborted"
+	case StatusWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("CheckStatus(%d)", int(s))
+	}
+}
+
+// isDone reports whether the status is a final one.
+func (s CheckStatus) isDone() bool {
+	switch s {
+	case StatusCompleted, StatusFailed, StatusCancelled, StatusSkipped, StatusAborted, StatusWarning:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	// ErrTimeout is wrapped by the error of a check that exceeded its own timeout
+	// or the deadline of the run.
+	ErrTimeout = errors.New("check timed out")
+	// ErrAborted is wrapped by the error of a check that was aborted because a
+	// required check failed in fail-fast mode.
+	ErrAborted = errors.New("aborted")
+	// ErrSkip can be returned, optionally wrapped, by a check function to
+	// signal that the check does not apply. The item is marked as StatusSkipped.
+	ErrSkip = errors.New("skipped")
+)
+
+// Skip returns an error wrapping ErrSkip with the given reason, for check
+// functions that find they do not apply, e.g. a GPU check on a machine
+// without GPU.
+func Skip(reason string) error {
+	return fmt.Errorf("%w: %s", ErrSkip, reason)
+}
+
+// WarningError is returned by a check function that completed, but found a
+// non-fatal problem worth reporting, e.g. a disk that is 85% full. The item is
+// marked as StatusWarning instead of StatusFailed.
+type WarningError struct {
+	Err error
+}
+
+// Warn returns a WarningError wrapping err.
+func Warn(err error) error {
+	return &WarningError{Err: err}
+}
+
+// Warnf returns a WarningError with a message formatted like fmt.Errorf.
+func Warnf(format string, args ...any) error {
+	return &WarningError{Err: fmt.Errorf(format, args...)}
+}
+
+func (e *WarningError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WarningError) Unwrap() error {
+	return e.Err
+}
+
 // SubProgressReporter is an interface for check functions to report sub-progress.
 type SubProgressReporter interface {
 	ReportSubProgress(percentage int, message string)
@@ -24,74 +109,366 @@ type SubProgressReporter interface {
 // It receives a SubProgressReporter to update its own progress.
 type CheckFunc func(reporter SubProgressReporter) error
 
+// CheckFuncContext is the signature for a context-aware check function.
+// The context is cancelled when the check should stop early, e.g. when the
+// manager is stopped or the UI quits, and the function should return promptly.
+type CheckFuncContext func(ctx context.Context, reporter SubProgressReporter) error
+
 // CheckItem represents a single check to be performed.
+// Its exported fields are updated while the check runs; use Snapshot to read
+// them from other goroutines.
 type CheckItem struct {
-	ID             int
-	Name           string
-	Status         CheckStatus
-	SubProgress    int    // Percentage for in-progress items (0-100)
-	SubMessage     string // Optional message for sub-progress
-	Error          error  // Stores the error if the check failed
-	runFunc        CheckFunc
-	mu             sync.Mutex // For thread-safe updates to Status, SubProgress, Error
-	reporterActive bool       // To ensure reporter is only used during execution
+	ID               int
+	Name             string
+	Status           CheckStatus
+	SubProgress      int         // Percentage for in-progress items (0-100)
+	SubMessage       string      // Optional message for sub-progress
+	Error            error       // Stores the error if the check failed, or why it was cancelled or skipped
+	Attempt          int         // Current or last attempt number, starting at 1
+	MaxAttempts      int         // Number of attempts allowed by the retry policy
+	Group            *CheckGroup // Group the check belongs to, nil if ungrouped
+	QueuedAt         time.Time   // When a run claimed the check, zero if it is not queued
+	StartedAt        time.Time   // When the check started running, zero if it has not started
+	FinishedAt       time.Time   // When the check reached a final status, zero if it is not done
+	runFunc          CheckFuncContext
+	timeout          time.Duration // Maximum duration of a single attempt, 0 means no limit
+	retry            RetryPolicy
+	deps             []dependency  // Checks that must complete before this one starts
+	mu               sync.Mutex    // For thread-safe updates to Status, SubProgress, Error
+	reporterActive   bool          // To ensure reporter is only used during execution
+	claimed          bool          // Whether a run has scheduled this item
+	manager          *CheckManager // Manager the item was added to, if any
+	run              *RunHandle    // Run executing the item, if any
+	priority         int           // Higher priorities are dispatched first
+	expectedDuration time.Duration // Hint to dispatch long checks first
+	resources        []string      // Named resources held while running
+	severity         Severity      // How much the outcome matters for the verdict
+
+	precondition func(ctx context.Context) error // Skips the check when it returns an error
+
+	logs        []LogEntry // Latest log entries, at most logLimit
+	logLimit    int        // 0 means DefaultLogLimit
+	logsDropped int        // Number of entries dropped because of the limit
+}
+
+// contextFunc adapts a CheckFunc to the CheckFuncContext signature.
+func contextFunc(fn CheckFunc) CheckFuncContext {
+	return func(_ context.Context, reporter SubProgressReporter) error {
+		return fn(reporter)
+	}
 }
 
 // NewCheckItem creates a new check item.
-func NewCheckItem(id int, name string, fn CheckFunc) *CheckItem {
-	return &CheckItem{
-		ID:      id,
-		Name:    name,
-		Status:  StatusPending,
-		runFunc: fn,
+func NewCheckItem(id int, name string, fn CheckFunc, opts ...CheckOption) *CheckItem {
+	return NewCheckItemContext(id, name, contextFunc(fn), opts...)
+}
+
+// NewCheckItemContext creates a new check item with a context-aware check function.
+func NewCheckItemContext(id int, name string, fn CheckFuncContext, opts ...CheckOption) *CheckItem {
+	ci := &CheckItem{
+		ID:          id,
+		Name:        name,
+		Status:      StatusPending,
+		MaxAttempts: 1,
+		runFunc:     fn,
 	}
+	for _, opt := range opts {
+		opt(ci)
+	}
+	return ci
+}
+
+// CheckSpawner is implemented by the reporter passed to checks executed by a
+// CheckManager. A running check can type-assert its reporter to CheckSpawner
+// to add follow-up checks to the current run, e.g. one check per discovered
+// volume. Spawned checks join the group of the spawning check unless InGroup
+// is given.
+type CheckSpawner interface {
+	SpawnCheck(name string, fn CheckFunc, opts ...CheckOption) (int, error)
+	SpawnCheckContext(name string, fn CheckFuncContext, opts ...CheckOption) (int, error)
 }
 
 // implement SubProgressReporter for CheckItem
 type checkItemReporter struct {
-	item *CheckItem
+	item    *CheckItem
+	attempt int // The attempt the reporter was handed to
+}
+
+// active reports whether the reporter's attempt is still running, so calls
+// of an attempt abandoned after a timeout don't affect the next one.
+// r.item.mu must be held.
+func (r *checkItemReporter) active() bool {
+	return r.item.Status == StatusInProgress && r.item.reporterActive && r.item.Attempt == r.attempt
+}
+
+func (r *checkItemReporter) SpawnCheck(name string, fn CheckFunc, opts ...CheckOption) (int, error) {
+	return r.SpawnCheckContext(name, contextFunc(fn), opts...)
+}
+
+func (r *checkItemReporter) SpawnCheckContext(name string, fn CheckFuncContext, opts ...CheckOption) (int, error) {
+	r.item.mu.Lock()
+	active := r.active()
+	manager, run := r.item.manager, r.item.run
+	r.item.mu.Unlock()
+
+	if !active || manager == nil || run == nil {
+		return 0, errors.New("tcheck: only checks running in a CheckManager can spawn checks")
+	}
+	opts = append([]CheckOption{InGroup(r.item.Group)}, opts...)
+	return manager.addCheck(run, name, fn, opts...)
 }
 
 func (r *checkItemReporter) ReportSubProgress(percentage int, message string) {
+	var progressChanged, messageChanged bool
 	r.item.mu.Lock()
-	defer r.item.mu.Unlock()
-	if r.item.Status == StatusInProgress && r.item.reporterActive {
+	if r.active() {
 		if percentage < 0 {
 			percentage = 0
 		}
 		if percentage > 100 {
 			percentage = 100
 		}
+		progressChanged = r.item.SubProgress != percentage
+		messageChanged = r.item.SubMessage != message
 		r.item.SubProgress = percentage
 		r.item.SubMessage = message
-		// Here you would typically send an event to the UI to redraw this item
-		// For this example, we'll just print, but in tcell you'd post an event.
-		// fmt.Printf("UI Event: Update item %d - SubProgress: %d%%, Message: %s\n", r.item.ID, percentage, message)
 	}
-	time.Sleep(200 * time.Millisecond)
+	r.item.mu.Unlock()
+
+	if progressChanged {
+		r.item.emit(EventCheckProgress, 0)
+	}
+	if messageChanged {
+		r.item.emit(EventCheckMessage, 0)
+	}
 }
 
 // Run executes the check function.
 func (ci *CheckItem) Run() {
+	ci.RunContext(context.Background())
+}
+
+// RunContext executes the check function with the given context.
+// If the context is cancelled before the check starts, or the check returns
+// an error after the context was cancelled, the item is marked as StatusCancelled.
+// If the check's timeout or the context's deadline expires, the item is marked
+// as StatusFailed with an error wrapping ErrTimeout. In both cases RunContext
+// returns without waiting for a check function that ignores its context.
+// Failed attempts are retried according to the policy set with WithRetry.
+// If the precondition set with WithPrecondition is not met, or the check
+// returns an error wrapping ErrSkip, the item is marked as StatusSkipped.
+// A check returning a *WarningError is marked as StatusWarning, and a check
+// that panics is marked as StatusFailed with a *PanicError.
+func (ci *CheckItem) RunContext(ctx context.Context) {
+	defer func() {
+		ci.emit(EventCheckFinished, ci.Snapshot().Duration())
+	}()
+
+	if ctx.Err() != nil {
+		ci.finish(doneStatus(ctx, false), contextError(ctx, 0))
+		return
+	}
+
 	ci.mu.Lock()
 	ci.Status = StatusInProgress
+	ci.Error = nil
+	ci.StartedAt = time.Now()
+	ci.FinishedAt = time.Time{}
+	ci.mu.Unlock()
+	ci.emit(EventCheckStarted, 0)
+
+	if ci.precondition != nil {
+		reason := recoverCall(func() error {
+			return ci.precondition(ctx)
+		})
+		if reason != nil {
+			status := StatusSkipped
+			if errors.As(reason, new(*PanicError)) {
+				status = StatusFailed
+			}
+			ci.finish(status, reason)
+			return
+		}
+	}
+
+	var status CheckStatus
+	var err error
+	for attempt := 1; ; attempt++ {
+		status, err = ci.runAttempt(ctx, attempt)
+		if status != StatusFailed || ctx.Err() != nil || attempt >= ci.MaxAttempts || !ci.retry.shouldRetry(err) {
+			break
+		}
+
+		// Wait before the next attempt, unless the run is aborted meanwhile
+		delay := ci.retry.delay(attempt)
+		ci.mu.Lock()
+		ci.SubMessage = fmt.Sprintf("Retrying in %s: %v", delay.Round(time.Millisecond), err)
+		ci.mu.Unlock()
+		ci.emit(EventCheckMessage, 0)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			status = doneStatus(ctx, true)
+			err = contextError(ctx, 0)
+			break
+		}
+	}
+
+	ci.finish(status, err)
+}
+
+// finish moves the item to its final status.
+func (ci *CheckItem) finish(status CheckStatus, err error) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	ci.Status = status
+	ci.Error = err
+	ci.FinishedAt = time.Now()
+	if status == StatusCompleted || status == StatusWarning {
+		ci.SubProgress = 100 // Ensure it shows 100% on completion
+	}
+}
+
+// runAttempt runs the check function once and returns the resulting status and error.
+func (ci *CheckItem) runAttempt(ctx context.Context, attempt int) (CheckStatus, error) {
+	ci.mu.Lock()
+	ci.Attempt = attempt
 	ci.SubProgress = 0
 	ci.SubMessage = ""
-	ci.Error = nil
 	ci.reporterActive = true
 	ci.mu.Unlock()
 
-	reporter := &checkItemReporter{item: ci}
-	err := ci.runFunc(reporter)
+	runCtx := ctx
+	if ci.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, ci.timeout)
+		defer cancel()
+	}
+
+	reporter := &checkItemReporter{item: ci, attempt: attempt}
+	done := make(chan error, 1) // Buffered so an abandoned check can still return
+	go func() {
+		done <- recoverCall(func() error {
+			return ci.runFunc(runCtx, reporter)
+		})
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-runCtx.Done():
+		err = runCtx.Err()
+	}
 
 	ci.mu.Lock()
 	ci.reporterActive = false
-	if err != nil {
-		ci.Status = StatusFailed
-		ci.Error = err
-	} else {
-		ci.Status = StatusCompleted
-		ci.SubProgress = 100 // Ensure it shows 100% on completion
+	ci.mu.Unlock()
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		status := doneStatus(ctx, true)
+		if status == StatusCancelled {
+			return status, err
+		}
+		return status, contextError(ctx, 0)
+	case err != nil && runCtx.Err() != nil:
+		// Only the check's own timeout expired
+		return StatusFailed, contextError(ctx, ci.timeout)
+	case errors.Is(err, ErrSkip):
+		return StatusSkipped, err
+	case errors.As(err, new(*WarningError)):
+		return StatusWarning, err
+	case err != nil:
+		return StatusFailed, err
+	default:
+		return StatusCompleted, nil
+	}
+}
+
+// doneStatus returns the status of a check interrupted because ctx is done.
+// When the run deadline expires, checks that already started fail while
+// checks that did not are cancelled.
+func doneStatus(ctx context.Context, started bool) CheckStatus {
+	switch {
+	case errors.Is(context.Cause(ctx), ErrAborted):
+		return StatusAborted
+	case started && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return StatusFailed
+	default:
+		return StatusCancelled
+	}
+}
+
+// contextError returns the error recorded on a check whose context is done.
+// Deadlines are reported as ErrTimeout: the run deadline if ctx itself expired,
+// otherwise the check's own timeout.
+func contextError(ctx context.Context, timeout time.Duration) error {
+	switch {
+	case errors.Is(context.Cause(ctx), ErrAborted):
+		return context.Cause(ctx)
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w: run deadline exceeded", ErrTimeout)
+	case ctx.Err() != nil:
+		return ctx.Err()
+	default:
+		return fmt.Errorf("%w after %s", ErrTimeout, timeout)
+	}
+}
+
+// status returns the current status of the item.
+func (ci *CheckItem) status() CheckStatus {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	return ci.Status
+}
+
+// reset moves a finished item that no run holds back to StatusPending.
+// It reports whether the item was reset.
+func (ci *CheckItem) reset() bool {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if !ci.Status.isDone() || ci.claimed {
+		return false
+	}
+	ci.Status = StatusPending
+	ci.SubProgress = 0
+	ci.SubMessage = ""
+	ci.Error = nil
+	ci.Attempt = 0
+	ci.QueuedAt = time.Time{}
+	ci.StartedAt = time.Time{}
+	ci.FinishedAt = time.Time{}
+	ci.logs = nil
+	ci.logsDropped = 0
+	return true
+}
+
+// interruptPending marks a check that has not started yet as interrupted
+// because ctx is done.
+func (ci *CheckItem) interruptPending(ctx context.Context) {
+	ci.finishPending(doneStatus(ctx, false), contextError(ctx, 0))
+}
+
+// skipPending marks a check that has not started yet as skipped.
+func (ci *CheckItem) skipPending(reason error) {
+	ci.finishPending(StatusSkipped, reason)
+}
+
+// finishPending moves a check that has not started yet to a final status.
+func (ci *CheckItem) finishPending(status CheckStatus, err error) {
+	ci.mu.Lock()
+	if ci.Status != StatusPending {
+		ci.mu.Unlock()
+		return
 	}
+	ci.Status = status
+	ci.Error = err
+	ci.FinishedAt = time.Now()
+	ci.claimed = false
 	ci.mu.Unlock()
+	ci.emit(EventCheckFinished, 0)
 }
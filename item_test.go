@@ -1,8 +1,10 @@
 package tcheck
 
 import (
+	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -153,7 +155,175 @@ func TestCheckItem_StatusTransitions(t *testing.T) {
 
 	time.Sleep(20 * time.Millisecond) // Wait for completion
 
-	if item.Status != StatusCompleted {
-		t.Errorf("expected StatusCompleted after run, got %v", item.Status)
+	if status := item.Snapshot().Status; status != StatusCompleted {
+		t.Errorf("expected StatusCompleted after run, got %v", status)
+	}
+}
+
+func TestCheckItem_RunContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fn := func(ctx context.Context, r SubProgressReporter) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	item := NewCheckItemContext(8, "cancel", fn)
+	item.RunContext(ctx)
+
+	if item.Status != StatusCancelled {
+		t.Errorf("expected StatusCancelled, got %v", item.Status)
+	}
+	if !errors.Is(item.Error, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", item.Error)
+	}
+}
+
+func TestCheckItem_RunContext_CancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	executed := false
+	item := NewCheckItem(9, "never", func(SubProgressReporter) error {
+		executed = true
+		return nil
+	})
+	item.RunContext(ctx)
+
+	if executed {
+		t.Error("check should not run with a cancelled context")
+	}
+	if item.Status != StatusCancelled {
+		t.Errorf("expected StatusCancelled, got %v", item.Status)
+	}
+}
+
+func TestCheckItem_Timeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	fn := func(r SubProgressReporter) error {
+		<-block // Never honours the timeout on its own
+		return nil
+	}
+	item := NewCheckItem(10, "hang", fn, WithTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	item.Run()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Run did not return after timeout, took %v", elapsed)
+	}
+
+	if item.Status != StatusFailed {
+		t.Errorf("expected StatusFailed, got %v", item.Status)
+	}
+	if !errors.Is(item.Error, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", item.Error)
+	}
+}
+
+func TestCheckItem_TimeoutStaleReporter(t *testing.T) {
+	stale := make(chan struct{})
+	reported := make(chan struct{})
+	var calls atomic.Int32
+	fn := func(r SubProgressReporter) error {
+		if calls.Add(1) == 1 {
+			// Keeps running after its attempt timed out
+			<-stale
+			r.ReportSubProgress(77, "stale attempt 1")
+			LoggerOf(r).Infof("stale log")
+			close(reported)
+			return nil
+		}
+		r.ReportSubProgress(10, "attempt 2")
+		close(stale)
+		<-reported
+		return errors.New("broken")
+	}
+	item := NewCheckItem(1, "retry", fn, WithTimeout(20*time.Millisecond), WithRetry(RetryPolicy{MaxAttempts: 2}))
+	item.Run()
+
+	s := item.Snapshot()
+	if s.Attempt != 2 || s.Status != StatusFailed {
+		t.Fatalf("Expected the second attempt to fail, got attempt %d: %v", s.Attempt, s.Status)
+	}
+	if s.SubProgress != 10 || s.SubMessage != "attempt 2" {
+		t.Errorf("Expected the progress of attempt 2, got %d%% %q", s.SubProgress, s.SubMessage)
+	}
+	if len(s.Logs) != 0 {
+		t.Errorf("Expected no logs from the timed out attempt, got %v", s.Logs)
+	}
+}
+
+func TestCheckItem_Run_Skip(t *testing.T) {
+	calls := 0
+	fn := func(SubProgressReporter) error {
+		calls++
+		return Skip("no GPU")
+	}
+	item := NewCheckItem(1, "skip", fn, WithRetry(RetryPolicy{MaxAttempts: 3}))
+	item.Run()
+
+	if item.Status != StatusSkipped {
+		t.Errorf("expected StatusSkipped, got %v", item.Status)
+	}
+	if !errors.Is(item.Error, ErrSkip) {
+		t.Errorf("expected error wrapping ErrSkip, got %v", item.Error)
+	}
+	if calls != 1 {
+		t.Errorf("expected skipped check not to be retried, got %d calls", calls)
+	}
+}
+
+func TestCheckItem_Run_Precondition(t *testing.T) {
+	called := false
+	fn := func(SubProgressReporter) error {
+		called = true
+		return nil
+	}
+	errNotLinux := errors.New("not linux")
+	item := NewCheckItem(1, "conditional", fn, WithPrecondition(func(context.Context) error {
+		return errNotLinux
+	}))
+	item.Run()
+
+	if item.Status != StatusSkipped {
+		t.Errorf("expected StatusSkipped, got %v", item.Status)
+	}
+	if !errors.Is(item.Error, errNotLinux) {
+		t.Errorf("expected precondition error, got %v", item.Error)
+	}
+	if called {
+		t.Error("expected check function not to be called")
+	}
+
+	item = NewCheckItem(2, "conditional", fn, WithPrecondition(func(context.Context) error {
+		return nil
+	}))
+	item.Run()
+	if item.Status != StatusCompleted || !called {
+		t.Errorf("expected check to run when the precondition is met, got %v", item.Status)
+	}
+}
+
+func TestCheckItem_Run_Warning(t *testing.T) {
+	calls := 0
+	fn := func(SubProgressReporter) error {
+		calls++
+		return Warnf("disk %d%% full", 85)
+	}
+	item := NewCheckItem(1, "warning", fn, WithRetry(RetryPolicy{MaxAttempts: 3}))
+	item.Run()
+
+	if item.Status != StatusWarning {
+		t.Errorf("expected StatusWarning, got %v", item.Status)
+	}
+	var warning *WarningError
+	if !errors.As(item.Error, &warning) || item.Error.Error() != "disk 85% full" {
+		t.Errorf("expected warning error, got %v", item.Error)
+	}
+	if item.SubProgress != 100 {
+		t.Errorf("expected SubProgress 100, got %d", item.SubProgress)
+	}
+	if calls != 1 {
+		t.Errorf("expected warning not to be retried, got %d calls", calls)
 	}
 }
@@ -0,0 +1,128 @@
+package tcheck
+
+import (
+	"fmt"
+	"time"
+)
+
+// LogLevel is the severity of a log entry of a check.
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// String returns the upper-case name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("LogLevel(%d)", int(l))
+	}
+}
+
+// LogEntry is a line logged by a check.
+type LogEntry struct {
+	Time    time.Time
+	Level   LogLevel
+	Message string
+}
+
+// String formats the entry as a single line.
+func (e LogEntry) String() string {
+	return fmt.Sprintf("%s %-5s %s", e.Time.Format("15:04:05.000"), e.Level, e.Message)
+}
+
+// DefaultLogLimit is the number of log entries kept per check, unless set with WithLogLimit.
+const DefaultLogLimit = 100
+
+// WithLogLimit sets how many log entries of the check are kept. Once the
+// limit is reached, the oldest entries are dropped.
+func WithLogLimit(limit int) CheckOption {
+	return func(ci *CheckItem) {
+		ci.logLimit = max(limit, 1)
+	}
+}
+
+// CheckLogger is implemented by the reporter passed to checks. Log lines are
+// kept in a bounded buffer per check, so a failed check leaves a trail of
+// what it did. Use LoggerOf to get the logger of a reporter.
+type CheckLogger interface {
+	Log(level LogLevel, format string, args ...any)
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// LoggerOf returns the logger of a reporter, or a logger discarding all lines
+// if the reporter does not implement CheckLogger.
+func LoggerOf(reporter SubProgressReporter) CheckLogger {
+	if logger, ok := reporter.(CheckLogger); ok {
+		return logger
+	}
+	return discardLogger{}
+}
+
+// discardLogger is a CheckLogger that drops all lines.
+type discardLogger struct{}
+
+func (discardLogger) Log(LogLevel, string, ...any) {}
+func (discardLogger) Debugf(string, ...any)        {}
+func (discardLogger) Infof(string, ...any)         {}
+func (discardLogger) Warnf(string, ...any)         {}
+func (discardLogger) Errorf(string, ...any)        {}
+
+func (r *checkItemReporter) Log(level LogLevel, format string, args ...any) {
+	entry := LogEntry{Time: time.Now(), Level: level, Message: fmt.Sprintf(format, args...)}
+	r.item.mu.Lock()
+	active := r.active()
+	if active {
+		r.item.appendLog(entry)
+	}
+	r.item.mu.Unlock()
+
+	if active {
+		r.item.emitLog(entry)
+	}
+}
+
+func (r *checkItemReporter) Debugf(format string, args ...any) {
+	r.Log(LogDebug, format, args...)
+}
+
+func (r *checkItemReporter) Infof(format string, args ...any) {
+	r.Log(LogInfo, format, args...)
+}
+
+func (r *checkItemReporter) Warnf(format string, args ...any) {
+	r.Log(LogWarn, format, args...)
+}
+
+func (r *checkItemReporter) Errorf(format string, args ...any) {
+	r.Log(LogError, format, args...)
+}
+
+// appendLog adds an entry to the log buffer, dropping the oldest entry once
+// the limit is reached. ci.mu must be held.
+func (ci *CheckItem) appendLog(entry LogEntry) {
+	limit := ci.logLimit
+	if limit == 0 {
+		limit = DefaultLogLimit
+	}
+	if dropped := len(ci.logs) - limit + 1; dropped > 0 {
+		ci.logs = append(ci.logs[:0], ci.logs[dropped:]...)
+		ci.logsDropped += dropped
+	}
+	ci.logs = append(ci.logs, entry)
+}
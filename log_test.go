@@ -0,0 +1,106 @@
+package tcheck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckLogger(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	events, unsubscribe := cm.SubscribeChan()
+	defer unsubscribe()
+
+	cm.AddCheck("logging", func(r SubProgressReporter) error {
+		log := LoggerOf(r)
+		log.Debugf("resolving %s", "db.local")
+		log.Infof("connected")
+		log.Warnf("slow response")
+		log.Errorf("query failed: %v", "timeout")
+		return errors.New("broken")
+	})
+
+	summary := cm.RunAllChecks().Wait()
+
+	logs := cm.Snapshot()[0].Logs
+	expected := []struct {
+		level   LogLevel
+		message string
+	}{
+		{LogDebug, "resolving db.local"},
+		{LogInfo, "connected"},
+		{LogWarn, "slow response"},
+		{LogError, "query failed: timeout"},
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("Expected %d log entries, got %v", len(expected), logs)
+	}
+	for i, e := range expected {
+		if logs[i].Level != e.level || logs[i].Message != e.message || logs[i].Time.IsZero() {
+			t.Errorf("Entry %d: expected %v %q, got %+v", i, e.level, e.message, logs[i])
+		}
+	}
+
+	if len(summary.Failures) != 1 || len(summary.Failures[0].Logs) != len(expected) {
+		t.Errorf("Expected the logs in the failure report, got %+v", summary.Failures)
+	}
+
+	var logged []string
+	for e := range events {
+		if e.Type == EventCheckLog {
+			logged = append(logged, e.Log.Message)
+		}
+		if e.Type == EventRunFinished {
+			break
+		}
+	}
+	if len(logged) != len(expected) {
+		t.Errorf("Expected a log event per entry, got %q", logged)
+	}
+
+	lines := strings.Join(detailLines(cm.Snapshot()[0]), "\n")
+	if !strings.Contains(lines, "ERROR query failed: timeout") {
+		t.Errorf("Expected the logs in the detail view, got %q", lines)
+	}
+}
+
+func TestCheckLogger_Limit(t *testing.T) {
+	item := NewCheckItem(1, "limited", func(r SubProgressReporter) error {
+		for i := range 5 {
+			LoggerOf(r).Infof("line %d", i)
+		}
+		return nil
+	}, WithLogLimit(3))
+	item.Run()
+
+	s := item.Snapshot()
+	if len(s.Logs) != 3 || s.LogsDropped != 2 {
+		t.Fatalf("Expected 3 entries and 2 dropped, got %d and %d", len(s.Logs), s.LogsDropped)
+	}
+	for i, entry := range s.Logs {
+		if expected := fmt.Sprintf("line %d", i+2); entry.Message != expected {
+			t.Errorf("Expected %q, got %q", expected, entry.Message)
+		}
+	}
+
+	// Logging after the check returned is ignored
+	var reporter SubProgressReporter
+	item = NewCheckItem(2, "late", func(r SubProgressReporter) error {
+		reporter = r
+		return nil
+	})
+	item.Run()
+	LoggerOf(reporter).Infof("too late")
+	if logs := item.Snapshot().Logs; len(logs) != 0 {
+		t.Errorf("Expected no log entries, got %v", logs)
+	}
+}
+
+type plainReporter struct{}
+
+func (plainReporter) ReportSubProgress(int, string) {}
+
+func TestLoggerOf_Discard(t *testing.T) {
+	LoggerOf(plainReporter{}).Infof("dropped") // Must not panic
+}
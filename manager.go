@@ -1,6 +1,10 @@
 package tcheck
 
 import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
 	"sync"
 	"time"
 )
@@ -10,32 +14,99 @@ type CheckManager struct {
 	items         []*CheckItem
 	mu            sync.RWMutex
 	itemCounter   int
-	uiUpdate      func() // Callback to trigger UI redraw
+	groups        []*CheckGroup
+	groupCounter  int
+	uiUpdate      func()        // Called after the state of checks changed, throttled by notifyUI
+	uiInterval    time.Duration // Minimum time between two calls of uiUpdate
+	uiMu          sync.Mutex    // Guards uiPending and uiNotifying
+	uiPending     bool          // Whether there are changes not yet passed to uiUpdate
+	uiNotifying   bool          // Whether notifyUI is running
+	dirty         chan struct{} // Holds a value while there are changes not yet taken by Changes
 	activeWorkers chan struct{}
+	runs          []*RunHandle  // Runs that have not finished yet, in the order they started
+	runTimeout    time.Duration // Deadline for a whole run, 0 means no limit
+	failFast      bool          // Abort a run when a required check fails
+	verdictPolicy VerdictPolicy // How outcomes are combined by Verdict
+
+	subscribersMu sync.Mutex // Guards subscribers, separate from mu so events can be published while it is held
+	subscribers   []*subscriber
+
+	resourceLimits    map[string]int // Concurrency limit per named resource
+	resourceUse       map[string]int // Number of running checks per named resource
+	resourcesReleased chan struct{}  // Closed and replaced whenever resources are released
 }
 
 // NewCheckManager creates a new CheckManager.
+// uiUpdateFunc, if not nil, is called after the state of checks changed. Like
+// Changes, it is coalesced: it is called from a separate goroutine, at most 30
+// times per second by default (see WithMaxUpdateRate), once for all changes
+// made since the previous call, and calls never overlap. A UIRenderer redraws
+// by itself, so it does not need this callback.
 // maxConcurrentChecks limits how many checks run at the same time.
-func NewCheckManager(uiUpdateFunc func(), maxConcurrentChecks int) *CheckManager {
+func NewCheckManager(uiUpdateFunc func(), maxConcurrentChecks int, opts ...ManagerOption) *CheckManager {
 	maxConcurrentChecks = max(maxConcurrentChecks, 1) // Default to at least one worker
 
-	return &CheckManager{
+	cm := &CheckManager{
 		items:         make([]*CheckItem, 0),
 		uiUpdate:      uiUpdateFunc,
+		uiInterval:    time.Second / defaultMaxUpdateRate,
+		dirty:         make(chan struct{}, 1),
 		activeWorkers: make(chan struct{}, maxConcurrentChecks),
+
+		resourceLimits:    make(map[string]int),
+		resourceUse:       make(map[string]int),
+		resourcesReleased: make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(cm)
+	}
+	return cm
 }
 
-// AddCheck adds a new check to the manager.
-func (cm *CheckManager) AddCheck(name string, fn CheckFunc) {
+// AddCheck adds a new check to the manager and returns its ID.
+// It returns an error wrapping ErrDependencyCycle, and does not add the check,
+// if the check's dependencies would form a cycle.
+// If a run is in progress, the check is executed by it; otherwise it stays
+// pending until the next run.
+func (cm *CheckManager) AddCheck(name string, fn CheckFunc, opts ...CheckOption) (int, error) {
+	return cm.addCheck(nil, name, contextFunc(fn), opts...)
+}
+
+// AddCheckContext adds a new context-aware check to the manager and returns its ID,
+// like AddCheck. The check's context is cancelled when the run is aborted or the
+// manager is stopped.
+func (cm *CheckManager) AddCheckContext(name string, fn CheckFuncContext, opts ...CheckOption) (int, error) {
+	return cm.addCheck(nil, name, fn, opts...)
+}
+
+// addCheck registers a new item and enqueues it in run, or in the latest run
+// in progress if run is nil.
+func (cm *CheckManager) addCheck(run *RunHandle, name string, fn CheckFuncContext, opts ...CheckOption) (int, error) {
 	cm.mu.Lock()
-	defer cm.mu.Unlock()
+	item := NewCheckItemContext(cm.itemCounter+1, name, fn, opts...)
+	if cycle := findCycle(item, append(slices.Clip(cm.items), item)); cycle != nil {
+		cm.mu.Unlock()
+		return 0, cycleError(cycle)
+	}
+	item.manager = cm
 	cm.itemCounter++
-	item := NewCheckItem(cm.itemCounter, name, fn)
 	cm.items = append(cm.items, item)
+
+	if run == nil && len(cm.runs) > 0 {
+		run = cm.runs[len(cm.runs)-1]
+	}
+	if run != nil {
+		cm.enqueueLocked(run, item)
+	}
+	cm.mu.Unlock()
+
+	cm.markDirty()
+	return item.ID, nil
 }
 
 // GetItems returns a thread-safe copy of the check items.
+// The state of running checks changes concurrently; use Snapshot, or the
+// Snapshot method of an item, to read it.
 func (cm *CheckManager) GetItems() []*CheckItem {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
@@ -47,84 +118,301 @@ func (cm *CheckManager) GetItems() []*CheckItem {
 }
 
 // RunAllChecks starts executing all pending checks.
-func (cm *CheckManager) RunAllChecks() {
-	itemsToRun := cm.GetItems() // Get a snapshot of items to run
+// It returns once every check has been dispatched; the returned handle can be
+// used to wait for the run to finish and get its summary.
+func (cm *CheckManager) RunAllChecks() *RunHandle {
+	return cm.RunAllChecksContext(context.Background())
+}
+
+// RunAllChecksContext starts executing all pending checks, like RunAllChecks.
+// Cancelling ctx, calling Cancel on the returned handle, or calling Stop aborts
+// the run: checks in progress receive a cancelled context and checks not yet
+// started are marked as StatusCancelled.
+// The run deadline set with WithRunTimeout is applied on top of ctx.
+func (cm *CheckManager) RunAllChecksContext(ctx context.Context) *RunHandle {
+	run := cm.Start(ctx)
+	<-run.dispatched
+	return run
+}
+
+// Start is like RunAllChecksContext, but returns immediately and dispatches
+// the checks in the background.
+// Checks added while the run is in progress are executed by it as well.
+func (cm *CheckManager) Start(ctx context.Context) *RunHandle {
+	var cancelTimeout context.CancelFunc
+	if cm.runTimeout > 0 {
+		ctx, cancelTimeout = context.WithTimeout(ctx, cm.runTimeout)
+	} else {
+		ctx, cancelTimeout = context.WithCancel(ctx)
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
 
-	var wg sync.WaitGroup
-	for _, item := range itemsToRun {
-		// Check if the item is pending before running
+	run := newRunHandle(func(cause error) {
+		cancel(cause)
+		cancelTimeout()
+	})
+
+	// Claim the pending items, they are dispatched once their dependencies are done
+	cm.mu.Lock()
+	for _, item := range cm.items {
 		item.mu.Lock()
-		isPending := item.Status == StatusPending
+		isPending := item.Status == StatusPending && !item.claimed
 		item.mu.Unlock()
-
 		if isPending {
-			wg.Add(1)
-			cm.activeWorkers <- struct{}{} // Acquire a worker slot
+			cm.enqueueLocked(run, item)
+		}
+	}
+	cm.runs = append(cm.runs, run)
+	cm.mu.Unlock()
+	cm.publish(Event{Type: EventRunStarted, Time: time.Now(), Run: run})
+
+	go cm.schedule(ctx, run)
+	return run
+}
 
-			go func(check *CheckItem) {
-				defer wg.Done()
-				defer func() { <-cm.activeWorkers }() // Release worker slot
+// enqueueLocked claims a pending item for the run. cm.mu must be held.
+func (cm *CheckManager) enqueueLocked(run *RunHandle, item *CheckItem) {
+	item.mu.Lock()
+	item.claimed = true
+	item.run = run
+	item.QueuedAt = time.Now()
+	item.mu.Unlock()
 
-				check.Run()
-				if cm.uiUpdate != nil {
-					cm.uiUpdate() // Signal UI to redraw after a check completes
-				}
-			}(item)
+	run.mu.Lock()
+	if !slices.Contains(run.items, item) {
+		run.items = append(run.items, item) // Re-run items are only counted once
+	}
+	run.mu.Unlock()
+
+	run.added = append(run.added, item)
+	select {
+	case run.wake <- struct{}{}:
+	default: // The dispatcher is already notified
+	}
+}
+
+// schedule starts the items of a run within the worker limit, once their
+// dependencies are done and their resources are available. Ready items are
+// started by descending priority, then by descending expected duration, then
+// in insertion order. It returns once all items, including the ones added
+// while the run is in progress, are done.
+func (cm *CheckManager) schedule(ctx context.Context, run *RunHandle) {
+	var items, queue []*CheckItem
+	// Executions of each item in the run that have not finished yet. An item
+	// re-run right after it finished may be added again before its finish is
+	// received, so the entries are counted.
+	scheduled := make(map[*CheckItem]int)
+	unschedule := func(item *CheckItem) {
+		if scheduled[item]--; scheduled[item] <= 0 {
+			delete(scheduled, item)
 		}
 	}
+	running := 0
+	finished := make(chan *CheckItem)
+	start := func(check *CheckItem) {
+		running++
+		run.wg.Add(1)
+		go func() {
+			defer run.wg.Done()
+			defer func() { finished <- check }()
+			defer func() { <-cm.activeWorkers }() // Release worker slot
+			defer cm.releaseResources(check)
 
-	// Periodically update UI for sub-progress, even if not all checks are done
-	// This is a simple approach; a more sophisticated one might use channels
-	// from each CheckItem to signal sub-progress updates.
-	go func() {
-		for {
-			allDone := true
-			items := cm.GetItems()
-			for _, item := range items {
-				item.mu.Lock()
-				status := item.Status
-				item.mu.Unlock()
-				if status == StatusPending || status == StatusInProgress {
-					allDone = false
-					break
+			check.RunContext(ctx)
+			check.mu.Lock()
+			check.claimed = false
+			check.mu.Unlock()
+			if cm.failFast {
+				check.mu.Lock()
+				requiredFailed := check.severity == SeverityRequired && check.Status == StatusFailed
+				check.mu.Unlock()
+				if requiredFailed {
+					run.cancel(fmt.Errorf("%w: required check %q failed", ErrAborted, check.Name))
 				}
 			}
+		}()
+	}
+
+	done := ctx.Done()
+	haveSlot := false // A worker slot acquired while waiting, for the next ready item
+	for {
+		// Take over the items added to the run, or finish it if nothing is left
+		cm.mu.Lock()
+		added := run.added
+		run.added = nil
+		if len(added) == 0 && len(queue) == 0 && running == 0 {
+			cm.runs = slices.DeleteFunc(cm.runs, func(r *RunHandle) bool { return r == run })
+			cm.mu.Unlock()
+			break
+		}
+		cm.mu.Unlock()
 
-			if cm.uiUpdate != nil {
-				cm.uiUpdate()
+		if len(added) > 0 {
+			items = cm.GetItems() // Refresh the snapshot used to resolve dependencies
+			for _, item := range added {
+				if ctx.Err() != nil {
+					item.interruptPending(ctx)
+					continue
+				}
+				queue = append(queue, item)
+				scheduled[item]++
 			}
+			slices.SortStableFunc(queue, func(a, b *CheckItem) int {
+				if a.priority != b.priority {
+					return cmp.Compare(b.priority, a.priority)
+				}
+				return cmp.Compare(b.expectedDuration, a.expectedDuration)
+			})
+		}
+
+		released := cm.resourcesReleasedChan() // Taken before checking resources to not miss a release
 
-			if allDone {
-				// One final update
-				if cm.uiUpdate != nil {
-					cm.uiUpdate()
+		// Skip items whose dependencies failed and dispatch ready ones while
+		// worker slots and their resources are free, until nothing changes anymore
+		var ready *CheckItem
+		waitUnknown := true
+		for changed := true; changed; {
+			changed = false
+			ready = nil
+			startable := false // An item only waits for a worker slot or resources
+			remaining := queue[:0]
+			for _, item := range queue {
+				isReady, err := dependencyState(item, items, scheduled, waitUnknown)
+				switch {
+				case err != nil:
+					item.skipPending(err)
+					unschedule(item)
+					changed = true
+					continue
+				case isReady && ready == nil && cm.resourcesAvailable(item):
+					if !haveSlot {
+						select {
+						case cm.activeWorkers <- struct{}{}: // Acquire a worker slot
+							haveSlot = true
+						default:
+							ready = item // Wait for a slot below
+						}
+					}
+					if haveSlot && cm.tryAcquireResources(item) {
+						haveSlot = false
+						start(item)
+						continue
+					}
 				}
-				return
+				if isReady {
+					startable = true
+				}
+				remaining = append(remaining, item)
+			}
+			queue = remaining
+			if !changed && waitUnknown && running == 0 && !startable && len(queue) > 0 {
+				// Nothing can add the missing dependencies anymore, skip their dependents
+				waitUnknown = false
+				changed = true
+			}
+		}
+		if haveSlot {
+			// No item could use the slot, give it back
+			<-cm.activeWorkers
+			haveSlot = false
+		}
+		if len(queue) == 0 {
+			run.markDispatched()
+			if running == 0 {
+				continue // Nothing to wait for, e.g. the last items were skipped
+			}
+		}
+
+		// Wait for a worker slot if an item is ready, for resources to be
+		// released, for items to be added, or for a check to finish
+		var slots chan struct{}
+		if ready != nil {
+			slots = cm.activeWorkers
+		}
+		select {
+		case slots <- struct{}{}:
+			haveSlot = true
+		case <-released:
+		case <-run.wake:
+		case check := <-finished:
+			running--
+			unschedule(check)
+		case <-done:
+			// Abort the run, items not started yet are cancelled
+			for _, item := range queue {
+				item.interruptPending(ctx)
 			}
-			time.Sleep(100 * time.Millisecond) // UI refresh rate for sub-progress
+			queue = nil
+			done = nil
 		}
-	}()
+	}
 
-	// wg.Wait() // Optionally wait for all to complete if RunAllChecks should be blocking
+	// Release the run once every dispatched check has returned
+	run.markDispatched()
+	run.wg.Wait()
+	run.Cancel()
+	run.finish()
+	summary := run.Summary()
+	cm.publish(Event{Type: EventRunFinished, Time: time.Now(), Run: run, Duration: summary.Duration, Summary: &summary})
 }
 
-// CalculateOverallProgress calculates the overall progress percentage.
-func (cm *CheckManager) CalculateOverallProgress() (int, int, int) {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
+// Changes returns a channel that receives a value when the state of checks
+// changed since the last receive. Changes made in between are coalesced, so
+// a slow consumer, like a UI limited to a frame rate, always sees the latest
+// state without processing every single change. The channel is meant for a
+// single consumer; use Subscribe to observe every change.
+func (cm *CheckManager) Changes() <-chan struct{} {
+	return cm.dirty
+}
 
-	if len(cm.items) == 0 {
-		return 0, 0, 0
+// markDirty signals that the state of checks changed.
+func (cm *CheckManager) markDirty() {
+	select {
+	case cm.dirty <- struct{}{}:
+	default: // A change is already pending
+	}
+	if cm.uiUpdate == nil {
+		return
 	}
 
-	completedCount := 0
-	for _, item := range cm.items {
-		item.mu.Lock()
-		if item.Status == StatusCompleted || item.Status == StatusFailed {
-			completedCount++
+	cm.uiMu.Lock()
+	defer cm.uiMu.Unlock()
+	cm.uiPending = true
+	if !cm.uiNotifying {
+		cm.uiNotifying = true
+		go cm.notifyUI()
+	}
+}
+
+// notifyUI calls uiUpdate for pending changes, waiting uiInterval after each
+// call so changes made in the meantime are coalesced. It returns once no
+// change is pending anymore.
+func (cm *CheckManager) notifyUI() {
+	for {
+		cm.uiMu.Lock()
+		if !cm.uiPending {
+			cm.uiNotifying = false
+			cm.uiMu.Unlock()
+			return
 		}
-		item.mu.Unlock()
+		cm.uiPending = false
+		cm.uiMu.Unlock()
+
+		cm.uiUpdate()
+		time.Sleep(cm.uiInterval)
 	}
-	totalCount := len(cm.items)
-	return completedCount, totalCount, (completedCount * 100) / totalCount
+}
+
+// Stop aborts all runs in progress, cancelling the context of running checks.
+func (cm *CheckManager) Stop() {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	for _, run := range cm.runs {
+		run.Cancel()
+	}
+}
+
+// CalculateOverallProgress calculates the overall progress percentage.
+func (cm *CheckManager) CalculateOverallProgress() (int, int, int) {
+	return progressOf(cm.Snapshot())
 }
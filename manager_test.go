@@ -1,7 +1,10 @@
 package tcheck
 
 import (
+	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -109,8 +112,8 @@ func TestCalculateOverallProgress(t *testing.T) {
 }
 
 func TestRunAllChecks(t *testing.T) {
-	updateCallCount := 0
-	updateFunc := func() { updateCallCount++ }
+	var updateCallCount atomic.Int32
+	updateFunc := func() { updateCallCount.Add(1) }
 
 	cm := NewCheckManager(updateFunc, 2)
 
@@ -144,7 +147,7 @@ func TestRunAllChecks(t *testing.T) {
 	}
 	mu.Unlock()
 
-	if updateCallCount == 0 {
+	if updateCallCount.Load() == 0 {
 		t.Error("UI update function was never called")
 	}
 }
@@ -206,9 +209,275 @@ func TestConcurrentAccess(t *testing.T) {
 	}
 }
 
+func TestStopCancelsRun(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	started := make(chan struct{})
+	cm.AddCheckContext("blocking", func(ctx context.Context, reporter SubProgressReporter) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cm.AddCheck("queued", testFunc)
+
+	go cm.RunAllChecks()
+	<-started
+	cm.Stop()
+	time.Sleep(100 * time.Millisecond)
+
+	for _, item := range cm.GetItems() {
+		item.mu.Lock()
+		status := item.Status
+		item.mu.Unlock()
+		if status != StatusCancelled {
+			t.Errorf("Expected %q to be cancelled, got %v", item.Name, status)
+		}
+	}
+
+	completed, total, _ := cm.CalculateOverallProgress()
+	if completed != total {
+		t.Errorf("Expected cancelled checks to count as done, got %d/%d", completed, total)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	cm := NewCheckManager(nil, 1, WithRunTimeout(50*time.Millisecond))
+
+	cm.AddCheckContext("slow", func(ctx context.Context, reporter SubProgressReporter) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cm.AddCheck("queued", testFunc)
+
+	cm.RunAllChecks()
+	time.Sleep(100 * time.Millisecond)
+
+	items := cm.GetItems()
+	items[0].mu.Lock()
+	if items[0].Status != StatusFailed || !errors.Is(items[0].Error, ErrTimeout) {
+		t.Errorf("Expected running check to time out, got %v (%v)", items[0].Status, items[0].Error)
+	}
+	items[0].mu.Unlock()
+
+	items[1].mu.Lock()
+	if items[1].Status != StatusCancelled || !errors.Is(items[1].Error, ErrTimeout) {
+		t.Errorf("Expected queued check to be cancelled by the deadline, got %v (%v)", items[1].Status, items[1].Error)
+	}
+	items[1].mu.Unlock()
+}
+
 func testFunc(reporter SubProgressReporter) error {
 	reporter.ReportSubProgress(0, "Starting...")
 	time.Sleep(50 * time.Millisecond)
 	reporter.ReportSubProgress(100, "Completed")
 	return nil
 }
+
+func TestFailFastAbortsRun(t *testing.T) {
+	cm := NewCheckManager(nil, 2, WithFailFast())
+
+	cm.AddCheck("critical", func(SubProgressReporter) error { return errors.New("broken") }, Required())
+	cm.AddCheckContext("slow", func(ctx context.Context, reporter SubProgressReporter) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cm.AddCheck("queued", testFunc)
+
+	summary := cm.RunAllChecks().Wait()
+
+	if summary.Counts[StatusFailed] != 1 || summary.Counts[StatusAborted] != 2 {
+		t.Errorf("Expected 1 failed and 2 aborted checks, got %v", summary.Counts)
+	}
+	for _, item := range cm.GetItems()[1:] {
+		item.mu.Lock()
+		if !errors.Is(item.Error, ErrAborted) {
+			t.Errorf("Expected %q to be aborted, got %v", item.Name, item.Error)
+		}
+		item.mu.Unlock()
+	}
+}
+
+func TestFailFastDefaultSeverity(t *testing.T) {
+	cm := NewCheckManager(nil, 1, WithFailFast())
+
+	cm.AddCheck("default", func(SubProgressReporter) error { return errors.New("broken") })
+	cm.AddCheck("next", testFunc)
+
+	summary := cm.RunAllChecks().Wait()
+
+	if summary.Counts[StatusFailed] != 1 || summary.Counts[StatusAborted] != 1 {
+		t.Errorf("Expected a failure of a check with the default severity to abort the run, got %v", summary.Counts)
+	}
+}
+
+func TestFailFastIgnoresOptionalChecks(t *testing.T) {
+	tests := map[string][]CheckOption{
+		"optional":          {WithSeverity(SeverityOptional)},
+		"informational":     {WithSeverity(SeverityInformational)},
+		"required optional": {Required(), WithSeverity(SeverityOptional)},
+	}
+	for name, opts := range tests {
+		cm := NewCheckManager(nil, 1, WithFailFast())
+
+		cm.AddCheck(name, func(SubProgressReporter) error { return errors.New("broken") }, opts...)
+		cm.AddCheck("next", func(SubProgressReporter) error { return nil })
+
+		summary := cm.RunAllChecks().Wait()
+
+		if summary.Counts[StatusCompleted] != 1 || summary.Counts[StatusAborted] != 0 {
+			t.Errorf("%s: expected the run to continue after the failure, got %v", name, summary.Counts)
+		}
+	}
+}
+
+func TestRunAllChecksPriorityOrder(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) CheckFunc {
+		return func(SubProgressReporter) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+
+	cm.AddCheck("low", record("low"))
+	cm.AddCheck("short", record("short"), WithExpectedDuration(time.Second))
+	cm.AddCheck("long", record("long"), WithExpectedDuration(time.Minute))
+	cm.AddCheck("high", record("high"), WithPriority(10))
+
+	cm.RunAllChecks().Wait()
+
+	expected := []string{"high", "long", "short", "low"}
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range expected {
+		if i >= len(order) || order[i] != expected[i] {
+			t.Fatalf("Expected order %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestAddCheckDuringRun(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+
+	release := make(chan struct{})
+	cm.AddCheckContext("running", func(ctx context.Context, reporter SubProgressReporter) error {
+		<-release
+		return nil
+	})
+
+	run := cm.Start(context.Background())
+	executed := make(chan struct{})
+	cm.AddCheck("late", func(SubProgressReporter) error {
+		close(executed)
+		return nil
+	})
+
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("Check added during the run was not executed")
+	}
+	if _, total, _ := cm.CalculateOverallProgress(); total != 2 {
+		t.Errorf("Expected total to include the added check, got %d", total)
+	}
+
+	close(release)
+	summary := run.Wait()
+	if summary.Total != 2 || summary.Counts[StatusCompleted] != 2 {
+		t.Errorf("Expected the run to include the added check, got %+v", summary)
+	}
+}
+
+func TestSpawnCheck(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+	storage := cm.AddGroup("Storage", nil)
+
+	var mu sync.Mutex
+	var checked []string
+	cm.AddCheck("Enumerate volumes", func(reporter SubProgressReporter) error {
+		spawner, ok := reporter.(CheckSpawner)
+		if !ok {
+			return errors.New("reporter cannot spawn checks")
+		}
+		for _, volume := range []string{"/", "/home", "/var"} {
+			_, err := spawner.SpawnCheck("Volume "+volume, func(SubProgressReporter) error {
+				mu.Lock()
+				checked = append(checked, volume)
+				mu.Unlock()
+				return nil
+			}, DependsOn("Enumerate volumes"))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, InGroup(storage))
+
+	summary := cm.RunAllChecks().Wait()
+
+	if summary.Total != 4 || summary.Counts[StatusCompleted] != 4 {
+		t.Errorf("Expected the spawned checks to run, got %+v", summary)
+	}
+	mu.Lock()
+	if len(checked) != 3 {
+		t.Errorf("Expected 3 volumes to be checked, got %v", checked)
+	}
+	mu.Unlock()
+	for _, item := range cm.GetItems() {
+		if item.Group != storage {
+			t.Errorf("Expected %q to inherit the spawning check's group", item.Name)
+		}
+	}
+}
+
+func TestChangesCoalesced(t *testing.T) {
+	var updates atomic.Int32
+	cm := NewCheckManager(func() { updates.Add(1) }, 1)
+
+	cm.AddCheck("first", testFunc)
+	cm.AddCheck("second", testFunc)
+	select {
+	case <-cm.Changes():
+	default:
+		t.Fatal("Expected a pending change after adding checks")
+	}
+	select {
+	case <-cm.Changes():
+		t.Fatal("Expected changes to be coalesced")
+	default:
+	}
+
+	cm.RunAllChecks().Wait()
+	select {
+	case <-cm.Changes():
+	default:
+		t.Error("Expected a pending change after the run")
+	}
+}
+
+func TestUpdateCallbackThrottled(t *testing.T) {
+	var updates atomic.Int32
+	cm := NewCheckManager(func() { updates.Add(1) }, 4, WithMaxUpdateRate(10))
+
+	for range 100 {
+		cm.AddCheck("check", func(SubProgressReporter) error { return nil })
+	}
+	cm.RunAllChecks().Wait()
+	if n := updates.Load(); n < 1 || n > 3 {
+		t.Errorf("Expected changes to be coalesced into few calls, got %d calls", n)
+	}
+
+	// The last changes are passed on once the interval has passed
+	time.Sleep(250 * time.Millisecond)
+	n := updates.Load()
+	cm.AddCheck("late", testFunc)
+	time.Sleep(250 * time.Millisecond)
+	if updates.Load() != n+1 {
+		t.Errorf("Expected one call for the late change, got %d", updates.Load()-n)
+	}
+}
@@ -0,0 +1,51 @@
+package netcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// DNSLookup returns a check that resolves host to IP addresses. If expected
+// addresses are given, each of them must be among the results.
+func DNSLookup(host string, expected ...string) tcheck.CheckFuncContext {
+	return DNSLookupWith(net.DefaultResolver, host, expected...)
+}
+
+// DNSLookupWith is like DNSLookup, but uses the given resolver, e.g. one
+// querying a specific name server.
+func DNSLookupWith(resolver *net.Resolver, host string, expected ...string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Resolving "+host)
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return err
+		}
+		tcheck.LoggerOf(reporter).Infof("%s resolves to %s", host, strings.Join(addrs, ", "))
+
+		for _, want := range expected {
+			found := slices.ContainsFunc(addrs, func(addr string) bool {
+				return sameIP(addr, want)
+			})
+			if !found {
+				return fmt.Errorf("%s does not resolve to %s, got %s", host, want, strings.Join(addrs, ", "))
+			}
+		}
+
+		reporter.ReportSubProgress(100, fmt.Sprintf("Resolved %s to %d addresses", host, len(addrs)))
+		return nil
+	}
+}
+
+// sameIP reports whether a and b are the same IP address, in any notation.
+func sameIP(a, b string) bool {
+	ipA, ipB := net.ParseIP(a), net.ParseIP(b)
+	if ipA == nil || ipB == nil {
+		return a == b
+	}
+	return ipA.Equal(ipB)
+}
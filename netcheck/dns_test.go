@@ -0,0 +1,35 @@
+package netcheck
+
+import (
+	"strings"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func TestDNSLookup(t *testing.T) {
+	s := tchecktest.Run(DNSLookup("localhost"))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+	if !strings.HasPrefix(s.SubMessage, "Resolved localhost to ") {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+
+	s = tchecktest.Run(DNSLookup("localhost", "192.0.2.1"))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "does not resolve to 192.0.2.1") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+
+	s = tchecktest.Run(DNSLookup("invalid host name"))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+}
+
+func TestSameIP(t *testing.T) {
+	if !sameIP("::1", "0:0:0:0:0:0:0:1") {
+		t.Error("Expected both notations of ::1 to be the same")
+	}
+	if sameIP("127.0.0.1", "127.0.0.2") {
+		t.Error("Expected different addresses to differ")
+	}
+}
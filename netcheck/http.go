@@ -0,0 +1,99 @@
+package netcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// maxBodySize limits how much of a response body is read for assertions.
+const maxBodySize = 1 << 20
+
+// HTTPOptions configures the assertions of HTTPGet. The zero value accepts
+// any 2xx response.
+type HTTPOptions struct {
+	Client       *http.Client  // Client used for the request, http.DefaultClient if nil
+	Header       http.Header   // Additional request headers
+	StatusCodes  []int         // Accepted status codes, any 2xx if empty
+	BodyContains string        // Text the body must contain, if not empty
+	BodyMatches  string        // Regular expression the body must match, if not empty
+	MaxLatency   time.Duration // Fail if the response takes longer, 0 means no limit
+	WarnLatency  time.Duration // Warn if the response takes longer, 0 means no limit
+}
+
+// HTTPGet returns a check that sends a GET request to url and asserts the
+// response according to opts. It panics if opts.BodyMatches does not compile.
+func HTTPGet(url string, opts HTTPOptions) tcheck.CheckFuncContext {
+	var bodyRegexp *regexp.Regexp
+	if opts.BodyMatches != "" {
+		bodyRegexp = regexp.MustCompile(opts.BodyMatches)
+	}
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		log := tcheck.LoggerOf(reporter)
+		reporter.ReportSubProgress(0, "GET "+url)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		for key, values := range opts.Header {
+			req.Header[key] = values
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		latency := time.Since(start)
+		log.Infof("%s responded with %s in %s", url, resp.Status, latency)
+
+		reporter.ReportSubProgress(50, "Checking response of "+url)
+		if !statusAccepted(resp.StatusCode, opts.StatusCodes) {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if opts.BodyContains != "" || bodyRegexp != nil {
+			body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+			if err != nil {
+				return fmt.Errorf("reading body: %w", err)
+			}
+			if opts.BodyContains != "" && !strings.Contains(string(body), opts.BodyContains) {
+				return fmt.Errorf("body does not contain %q", opts.BodyContains)
+			}
+			if bodyRegexp != nil && !bodyRegexp.Match(body) {
+				return fmt.Errorf("body does not match %q", opts.BodyMatches)
+			}
+		}
+		latency = time.Since(start) // Including the body
+
+		switch {
+		case opts.MaxLatency > 0 && latency > opts.MaxLatency:
+			return fmt.Errorf("response took %s, more than %s", latency.Round(time.Millisecond), opts.MaxLatency)
+		case opts.WarnLatency > 0 && latency > opts.WarnLatency:
+			return tcheck.Warnf("response took %s, more than %s", latency.Round(time.Millisecond), opts.WarnLatency)
+		}
+
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s in %s", resp.Status, latency.Round(time.Millisecond)))
+		return nil
+	}
+}
+
+// statusAccepted reports whether code is among accepted, or a 2xx code if accepted is empty.
+func statusAccepted(code int, accepted []int) bool {
+	if len(accepted) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(accepted, code)
+}
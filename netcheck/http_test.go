@@ -0,0 +1,82 @@
+package netcheck
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "status: ok, agent: %s", r.Header.Get("X-Agent"))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, "slow")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPGet(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		opts    HTTPOptions
+		status  tcheck.CheckStatus
+		errText string
+	}{
+		{"ok", "/ok", HTTPOptions{}, tcheck.StatusCompleted, ""},
+		{"not found", "/missing", HTTPOptions{}, tcheck.StatusFailed, "404"},
+		{"accepted status", "/missing", HTTPOptions{StatusCodes: []int{404}}, tcheck.StatusCompleted, ""},
+		{"body contains", "/ok", HTTPOptions{BodyContains: "status: ok"}, tcheck.StatusCompleted, ""},
+		{"body missing", "/ok", HTTPOptions{BodyContains: "error"}, tcheck.StatusFailed, "does not contain"},
+		{"body matches", "/ok", HTTPOptions{BodyMatches: `^status: \w+`}, tcheck.StatusCompleted, ""},
+		{"body mismatch", "/ok", HTTPOptions{BodyMatches: `^error`}, tcheck.StatusFailed, "does not match"},
+		{"header", "/ok", HTTPOptions{Header: http.Header{"X-Agent": {"tcheck"}}, BodyContains: "agent: tcheck"}, tcheck.StatusCompleted, ""},
+		{"too slow", "/slow", HTTPOptions{MaxLatency: 10 * time.Millisecond}, tcheck.StatusFailed, "more than 10ms"},
+		{"slow warning", "/slow", HTTPOptions{MaxLatency: time.Minute, WarnLatency: 10 * time.Millisecond}, tcheck.StatusWarning, "more than 10ms"},
+		{"fast enough", "/slow", HTTPOptions{MaxLatency: time.Minute, WarnLatency: time.Minute}, tcheck.StatusCompleted, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tchecktest.Run(HTTPGet(server.URL+tt.path, tt.opts))
+			tchecktest.ExpectStatus(t, s, tt.status)
+			if tt.errText != "" && !strings.Contains(s.Error.Error(), tt.errText) {
+				t.Errorf("Expected %q in the error, got %v", tt.errText, s.Error)
+			}
+		})
+	}
+}
+
+func TestHTTPGet_Progress(t *testing.T) {
+	server := newTestServer(t)
+	s := tchecktest.Run(HTTPGet(server.URL+"/ok", HTTPOptions{}))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+	if s.SubProgress != 100 || !strings.HasPrefix(s.SubMessage, "200 OK in ") {
+		t.Errorf("Unexpected progress %d%% %q", s.SubProgress, s.SubMessage)
+	}
+	if len(s.Logs) == 0 || !strings.Contains(s.Logs[0].Message, "responded with 200 OK") {
+		t.Errorf("Expected the response to be logged, got %v", s.Logs)
+	}
+}
+
+func TestHTTPGet_Timeout(t *testing.T) {
+	server := newTestServer(t)
+	s := tchecktest.Run(HTTPGet(server.URL+"/slow", HTTPOptions{}), tcheck.WithTimeout(10*time.Millisecond))
+	if s.Status != tcheck.StatusFailed || !strings.Contains(s.Error.Error(), "timed out") {
+		t.Errorf("Expected the request to time out, got %v: %v", s.Status, s.Error)
+	}
+}
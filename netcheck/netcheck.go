@@ -0,0 +1,104 @@
+// Package netcheck provides ready-made network checks for tcheck: TCP dial,
+// UDP probe, DNS resolution, HTTP(S) GET, TLS handshake and free ports.
+//
+// The constructors return context-aware check functions, to be added with
+// CheckManager.AddCheckContext; use tcheck.WithTimeout to limit how long a
+// check may take.
+//
+//	manager.AddCheckContext("Database Port", netcheck.TCPDial("db.local:5432"), tcheck.WithTimeout(5*time.Second))
+package netcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// DefaultUDPTimeout is how long UDPProbe waits for a reply if the context has no deadline.
+const DefaultUDPTimeout = 2 * time.Second
+
+// TCPDial returns a check that connects to the TCP address, e.g. "db.local:5432".
+func TCPDial(address string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Connecting to "+address)
+		start := time.Now()
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		tcheck.LoggerOf(reporter).Infof("connected to %s in %s", conn.RemoteAddr(), time.Since(start))
+		reporter.ReportSubProgress(100, "Connected to "+address)
+		return nil
+	}
+}
+
+// UDPProbe returns a check that sends payload to the UDP address and expects
+// a reply. It fails if the port is closed or no reply arrives before the
+// context is done, or within DefaultUDPTimeout if the context has no deadline.
+func UDPProbe(address string, payload []byte) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Probing "+address)
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "udp", address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(DefaultUDPTimeout)
+		}
+		conn.SetDeadline(deadline)
+		stop := context.AfterFunc(ctx, func() {
+			conn.SetDeadline(time.Now()) // Unblock the read on cancellation
+		})
+		defer stop()
+
+		start := time.Now()
+		if _, err := conn.Write(payload); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(50, "Waiting for a reply from "+address)
+		buf := make([]byte, 64*1024)
+		n, err := conn.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return fmt.Errorf("no reply from %s: %w", address, err)
+			}
+			return err
+		}
+
+		tcheck.LoggerOf(reporter).Infof("received %d bytes from %s in %s", n, address, time.Since(start))
+		reporter.ReportSubProgress(100, "Reply from "+address)
+		return nil
+	}
+}
+
+// PortFree returns a check that succeeds if nothing listens on the TCP
+// address yet, e.g. ":8080", so a service can bind it.
+func PortFree(address string) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Binding "+address)
+		var lc net.ListenConfig
+		ln, err := lc.Listen(ctx, "tcp", address)
+		if err != nil {
+			return fmt.Errorf("port %s is not free: %w", address, err)
+		}
+		ln.Close()
+
+		reporter.ReportSubProgress(100, "Port "+address+" is free")
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package netcheck
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+// closedAddress returns an address of the network that nothing listens on.
+func closedAddress(t *testing.T, network string) string {
+	t.Helper()
+	var address string
+	switch network {
+	case "tcp":
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		address = ln.Addr().String()
+		ln.Close()
+	case "udp":
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		address = conn.LocalAddr().String()
+		conn.Close()
+	}
+	return address
+}
+
+func TestTCPDial(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	s := tchecktest.Run(TCPDial(ln.Addr().String()))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+	if s.SubMessage != "Connected to "+ln.Addr().String() {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+
+	s = tchecktest.Run(TCPDial(closedAddress(t, "tcp")))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+}
+
+func TestUDPProbe(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if string(buf[:n]) == "ping" {
+				conn.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+
+	s := tchecktest.Run(UDPProbe(conn.LocalAddr().String(), []byte("ping")))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+
+	// The server ignores other payloads
+	s = tchecktest.Run(UDPProbe(conn.LocalAddr().String(), []byte("other")), tcheck.WithTimeout(200*time.Millisecond))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+
+	// A closed port is refused, without waiting for the timeout
+	start := time.Now()
+	s = tchecktest.Run(UDPProbe(closedAddress(t, "udp"), []byte("ping")))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if elapsed := time.Since(start); elapsed >= DefaultUDPTimeout {
+		t.Errorf("Expected the refused port to fail fast, took %s", elapsed)
+	}
+}
+
+func TestUDPProbe_Cancel(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	item := tcheck.NewCheckItemContext(1, "udp", UDPProbe(conn.LocalAddr().String(), []byte("ping")))
+	done := make(chan struct{})
+	go func() {
+		item.RunContext(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected cancellation to unblock the probe")
+	}
+	if s := item.Snapshot(); s.Status != tcheck.StatusCancelled {
+		t.Errorf("Expected StatusCancelled, got %v: %v", s.Status, s.Error)
+	}
+}
+
+func TestPortFree(t *testing.T) {
+	s := tchecktest.Run(PortFree(closedAddress(t, "tcp")))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	s = tchecktest.Run(PortFree(ln.Addr().String()))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusFailed)
+	if !strings.Contains(s.Error.Error(), "is not free") {
+		t.Errorf("Unexpected error %v", s.Error)
+	}
+}
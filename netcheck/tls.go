@@ -0,0 +1,109 @@
+package netcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// DefaultExpiryWarning is how long before expiry TLSHandshake warns about a
+// certificate, unless set in TLSOptions.
+const DefaultExpiryWarning = 30 * 24 * time.Hour
+
+// TLSOptions configures TLSHandshake.
+type TLSOptions struct {
+	// Config is used for the handshake, e.g. to set RootCAs. If its ServerName
+	// is empty, the host of the address is used.
+	Config *tls.Config
+	// WarnBefore makes the check warn when a certificate of the chain expires
+	// within this duration; DefaultExpiryWarning if 0, never if negative.
+	WarnBefore time.Duration
+	// FailBefore makes the check fail when a certificate of the chain expires
+	// within this duration, 0 means only expired certificates fail.
+	FailBefore time.Duration
+}
+
+// TLSHandshake returns a check that performs a TLS handshake with the TCP
+// address, e.g. "example.com:443", verifying the certificate chain. It warns
+// or fails when a certificate expires soon, according to opts.
+func TLSHandshake(address string, opts TLSOptions) tcheck.CheckFuncContext {
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		config := &tls.Config{}
+		if opts.Config != nil {
+			config = opts.Config.Clone()
+		}
+		if config.ServerName == "" {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			config.ServerName = host
+		}
+
+		reporter.ReportSubProgress(0, "Connecting to "+address)
+		dialer := &tls.Dialer{Config: config}
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		reporter.ReportSubProgress(50, "Checking certificates of "+address)
+		state := conn.(*tls.Conn).ConnectionState()
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("no peer certificate")
+		}
+		cert := earliestExpiry(state.PeerCertificates)
+		remaining := time.Until(cert.NotAfter)
+		tcheck.LoggerOf(reporter).Infof("%s: %s, certificate %q expires %s",
+			address, tls.VersionName(state.Version), cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+
+		warnBefore := opts.WarnBefore
+		if warnBefore == 0 {
+			warnBefore = DefaultExpiryWarning
+		}
+		switch {
+		case remaining <= opts.FailBefore:
+			return fmt.Errorf("certificate %q expires %s", cert.Subject.CommonName, expiryText(remaining))
+		case warnBefore > 0 && remaining <= warnBefore:
+			return tcheck.Warnf("certificate %q expires %s", cert.Subject.CommonName, expiryText(remaining))
+		}
+
+		reporter.ReportSubProgress(100, "Certificate valid for "+formatDays(remaining))
+		return nil
+	}
+}
+
+// earliestExpiry returns the certificate of the chain that expires first.
+func earliestExpiry(certs []*x509.Certificate) *x509.Certificate {
+	earliest := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(earliest.NotAfter) {
+			earliest = cert
+		}
+	}
+	return earliest
+}
+
+// expiryText describes when a certificate expires, relative to now.
+func expiryText(remaining time.Duration) string {
+	if remaining <= 0 {
+		return formatDays(-remaining) + " ago"
+	}
+	return "in " + formatDays(remaining)
+}
+
+// formatDays formats d in days.
+func formatDays(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
@@ -0,0 +1,74 @@
+package netcheck
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+// newTLSServer returns a TLS server and a config trusting its certificate.
+func newTLSServer(t *testing.T) (*httptest.Server, *tls.Config) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(nil)
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Untrusted clients abort handshakes
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return server, &tls.Config{RootCAs: pool, ServerName: "example.com"}
+}
+
+func TestTLSHandshake(t *testing.T) {
+	server, config := newTLSServer(t)
+	address := server.Listener.Addr().String()
+	// The httptest certificate is valid until 2084
+	remaining := time.Until(server.Certificate().NotAfter)
+
+	tests := []struct {
+		name    string
+		opts    TLSOptions
+		status  tcheck.CheckStatus
+		errText string
+	}{
+		{"valid", TLSOptions{Config: config}, tcheck.StatusCompleted, ""},
+		{"expires soon", TLSOptions{Config: config, WarnBefore: remaining + 24*time.Hour}, tcheck.StatusWarning, "expires in"},
+		{"no warning", TLSOptions{Config: config, WarnBefore: -1}, tcheck.StatusCompleted, ""},
+		{"fail before", TLSOptions{Config: config, FailBefore: remaining + 24*time.Hour}, tcheck.StatusFailed, "expires in"},
+		{"untrusted", TLSOptions{}, tcheck.StatusFailed, "certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tchecktest.Run(TLSHandshake(address, tt.opts))
+			tchecktest.ExpectStatus(t, s, tt.status)
+			if tt.errText != "" && !strings.Contains(s.Error.Error(), tt.errText) {
+				t.Errorf("Expected %q in the error, got %v", tt.errText, s.Error)
+			}
+		})
+	}
+}
+
+func TestTLSHandshake_Progress(t *testing.T) {
+	server, config := newTLSServer(t)
+	s := tchecktest.Run(TLSHandshake(server.Listener.Addr().String(), TLSOptions{Config: config}))
+	tchecktest.ExpectStatus(t, s, tcheck.StatusCompleted)
+	if s.SubProgress != 100 || !strings.HasPrefix(s.SubMessage, "Certificate valid for ") {
+		t.Errorf("Unexpected progress %d%% %q", s.SubProgress, s.SubMessage)
+	}
+}
+
+func TestExpiryText(t *testing.T) {
+	if got := expiryText(36 * time.Hour); got != "in 1 day" {
+		t.Errorf("Unexpected %q", got)
+	}
+	if got := expiryText(-72 * time.Hour); got != "3 days ago" {
+		t.Errorf("Unexpected %q", got)
+	}
+}
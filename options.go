@@ -0,0 +1,86 @@
+package tcheck
+
+import (
+	"context"
+	"time"
+)
+
+// CheckOption configures a check when it is added to a CheckManager.
+type CheckOption func(*CheckItem)
+
+// WithTimeout limits how long each attempt of the check may run. When the
+// timeout expires the check's context is cancelled and the item is marked as
+// failed with an error wrapping ErrTimeout, even if the check function never returns.
+func WithTimeout(d time.Duration) CheckOption {
+	return func(ci *CheckItem) {
+		ci.timeout = d
+	}
+}
+
+// Required marks the check as required, i.e. sets its severity to
+// SeverityRequired, the default: in fail-fast mode its failure aborts the run.
+// It is the same as WithSeverity(SeverityRequired).
+func Required() CheckOption {
+	return WithSeverity(SeverityRequired)
+}
+
+// WithPriority sets the priority of the check. When worker slots are scarce,
+// ready checks with a higher priority are started first. The default is 0.
+func WithPriority(priority int) CheckOption {
+	return func(ci *CheckItem) {
+		ci.priority = priority
+	}
+}
+
+// WithExpectedDuration hints how long the check usually takes. Among ready
+// checks of the same priority, the longest expected ones are started first so
+// they do not end up delaying the whole run.
+func WithExpectedDuration(d time.Duration) CheckOption {
+	return func(ci *CheckItem) {
+		ci.expectedDuration = d
+	}
+}
+
+// WithPrecondition makes the check conditional: right before the check would
+// start, the precondition is evaluated, and if it returns an error the check
+// is skipped with that error as the reason instead of being run.
+func WithPrecondition(precondition func(ctx context.Context) error) CheckOption {
+	return func(ci *CheckItem) {
+		ci.precondition = precondition
+	}
+}
+
+// ManagerOption configures a CheckManager.
+type ManagerOption func(*CheckManager)
+
+// WithRunTimeout sets a deadline for a whole run. Checks still running when it
+// expires fail with an error wrapping ErrTimeout, and checks not started yet
+// are cancelled.
+func WithRunTimeout(d time.Duration) ManagerOption {
+	return func(cm *CheckManager) {
+		cm.runTimeout = d
+	}
+}
+
+// defaultMaxUpdateRate is the default of WithMaxUpdateRate, matching the
+// default frame rate of a UIRenderer.
+const defaultMaxUpdateRate = 30
+
+// WithMaxUpdateRate limits how many times per second the update callback
+// passed to NewCheckManager is called. The default is 30.
+func WithMaxUpdateRate(perSecond int) ManagerOption {
+	return func(cm *CheckManager) {
+		cm.uiInterval = time.Second / time.Duration(max(perSecond, 1))
+	}
+}
+
+// WithFailFast aborts a run as soon as a required check, i.e. one with
+// SeverityRequired, the default, fails: pending checks are no longer started
+// and running ones are cancelled, and all of them are marked as StatusAborted
+// with an error wrapping ErrAborted. Optional and informational checks may
+// fail without aborting the run.
+func WithFailFast() ManagerOption {
+	return func(cm *CheckManager) {
+		cm.failFast = true
+	}
+}
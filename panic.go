@@ -0,0 +1,33 @@
+package tcheck
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error of a check whose function panicked. The check is
+// marked as StatusFailed, and the other checks keep running.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, e.g. a runtime.Error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// recoverCall calls fn and returns a *PanicError if it panics.
+func recoverCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
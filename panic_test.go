@@ -0,0 +1,65 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCheckItem_Run_Panic(t *testing.T) {
+	fn := func(SubProgressReporter) error {
+		var m map[string]int
+		m["boom"]++ // Panics with a runtime error
+		return nil
+	}
+	item := NewCheckItem(1, "panic", fn)
+	item.Run()
+
+	if item.Status != StatusFailed {
+		t.Errorf("expected StatusFailed, got %v", item.Status)
+	}
+	var panicErr *PanicError
+	if !errors.As(item.Error, &panicErr) {
+		t.Fatalf("expected a PanicError, got %v", item.Error)
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(item.Error, &runtimeErr) {
+		t.Errorf("expected the error to wrap the runtime error, got %v", item.Error)
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestCheckItem_Run_Panic") {
+		t.Errorf("expected the stack to contain the check function, got %s", panicErr.Stack)
+	}
+}
+
+func TestRunAllChecks_PanicKeepsOthersRunning(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+	cm.AddCheck("panic", func(SubProgressReporter) error { panic("unexpected") })
+	cm.AddCheck("ok", testFunc)
+	cm.AddCheck("conditional", testFunc, WithPrecondition(func(context.Context) error { panic("unexpected") }))
+
+	summary := cm.RunAllChecks().Wait()
+	if summary.Counts[StatusFailed] != 2 || summary.Counts[StatusCompleted] != 1 {
+		t.Errorf("Unexpected counts %v", summary.Counts)
+	}
+	for _, fail := range summary.Failures {
+		var panicErr *PanicError
+		if !errors.As(fail.Err, &panicErr) || panicErr.Value != "unexpected" {
+			t.Errorf("Expected %q to fail with the panic value, got %v", fail.Name, fail.Err)
+		}
+	}
+}
+
+func TestDetailLines_Stack(t *testing.T) {
+	item := NewCheckItem(1, "panic", func(SubProgressReporter) error { panic("unexpected") })
+	item.Run()
+
+	lines := detailLines(item.Snapshot())
+	if lines[0] != "panic" || !strings.Contains(strings.Join(lines, "\n"), "panic: unexpected") {
+		t.Errorf("Expected the name and error in the detail view, got %q", lines)
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "Stack:") {
+		t.Errorf("Expected the stack in the detail view, got %q", lines)
+	}
+}
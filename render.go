@@ -1,14 +1,19 @@
 package tcheck
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+// elapsedRefreshInterval is how often the elapsed time of running checks is updated.
+const elapsedRefreshInterval = 100 * time.Millisecond
+
 // UIRenderer handles the Tcell display.
 type UIRenderer struct {
 	screen              tcell.Screen
@@ -17,12 +22,19 @@ type UIRenderer struct {
 	StyleGood           tcell.Style
 	StyleBad            tcell.Style
 	StyleWarning        tcell.Style
+	StyleSkipped        tcell.Style
 	StyleScrollBar      tcell.Style
 	StyleScrollBarThumb tcell.Style
 	StyleScrollBarArrow tcell.Style
 	StyleProgress       tcell.Style
+	AutoQuit            bool       // Quit once all checks are done; disable to re-run checks from the UI
+	MaxFPS              int        // Maximum number of redraws per second caused by changes of checks
 	mu                  sync.Mutex // For screen operations
 	scrollTop           int        // Top visible item index for scrolling
+	selected            int        // Index of the selected row
+	detail              int        // ID of the check shown in the detail view, 0 for the list
+	detailTop           int        // Top visible line of the detail view
+	running             bool       // Whether checks were running in the last frame
 	quit                chan struct{}
 	quitOnce            sync.Once // Ensure quit channel is closed only once
 }
@@ -36,10 +48,13 @@ func NewUIRenderer(s tcell.Screen, cm *CheckManager) *UIRenderer {
 		StyleGood:           tcell.StyleDefault.Foreground(tcell.ColorGreen).Background(tcell.ColorNone),
 		StyleBad:            tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorNone),
 		StyleWarning:        tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorNone),
+		StyleSkipped:        tcell.StyleDefault.Foreground(tcell.ColorGray).Background(tcell.ColorNone),
 		StyleScrollBar:      tcell.StyleDefault.Foreground(tcell.ColorDarkGray).Background(tcell.ColorNone),
 		StyleScrollBarThumb: tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorNone),
 		StyleScrollBarArrow: tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorNone),
 		StyleProgress:       tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorTeal),
+		AutoQuit:            true,
+		MaxFPS:              30,
 		scrollTop:           0,
 		quit:                make(chan struct{}),
 		quitOnce:            sync.Once{},
@@ -88,6 +103,155 @@ func (ui *UIRenderer) drawScrollBar(width, height, numItems, displayableRows int
 	}
 }
 
+// row is a line of the check list: either a group header or a check item.
+type row struct {
+	depth int
+	group *CheckGroup
+	item  *CheckSnapshot
+}
+
+// layoutRows arranges items below the headers of their groups. A group is
+// shown where its first check would be, and groups without checks are hidden.
+func layoutRows(items []CheckSnapshot) []row {
+	var rows []row
+	shown := make(map[*CheckGroup]bool)
+
+	// childOf returns the group on the path to item that is a direct child of
+	// parent (nil for top-level), or nil if item is directly in parent.
+	childOf := func(parent *CheckGroup, item *CheckSnapshot) *CheckGroup {
+		for g := item.Group; g != nil; g = g.Parent {
+			if g.Parent == parent {
+				return g
+			}
+		}
+		return nil
+	}
+
+	var addGroup func(group *CheckGroup, depth int)
+	addGroup = func(group *CheckGroup, depth int) {
+		shown[group] = true
+		rows = append(rows, row{depth: depth, group: group})
+		for i := range items {
+			item := &items[i]
+			if !group.containsGroup(item.Group) {
+				continue
+			}
+			if item.Group == group {
+				rows = append(rows, row{depth: depth + 1, item: item})
+			} else if child := childOf(group, item); !shown[child] {
+				addGroup(child, depth+1)
+			}
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		if item.Group == nil {
+			rows = append(rows, row{item: item})
+		} else if top := childOf(nil, item); !shown[top] {
+			addGroup(top, 0)
+		}
+	}
+	return rows
+}
+
+// statusIcon returns the icon shown in front of a check or group with the given status.
+func statusIcon(status CheckStatus) string {
+	switch status {
+	case StatusCompleted:
+		return "✅"
+	case StatusFailed:
+		return "❌"
+	case StatusCancelled:
+		return "🚫"
+	case StatusAborted:
+		return "⛔"
+	case StatusSkipped:
+		return "⏩"
+	case StatusWarning:
+		return "⚠"
+	case StatusInProgress:
+		return "⏳"
+	default:
+		return "-"
+	}
+}
+
+// statusStyle returns the style of a check or group with the given status.
+func (ui *UIRenderer) statusStyle(status CheckStatus) tcell.Style {
+	switch status {
+	case StatusCompleted:
+		return ui.StyleGood
+	case StatusFailed:
+		return ui.StyleBad
+	case StatusCancelled, StatusAborted, StatusInProgress, StatusWarning:
+		return ui.StyleWarning
+	case StatusSkipped:
+		return ui.StyleSkipped
+	default:
+		return ui.StyleDefault
+	}
+}
+
+// groupLine returns the header line of a group with the rolled-up status and
+// progress of its checks among items.
+func (ui *UIRenderer) groupLine(group *CheckGroup, items []CheckSnapshot) (string, tcell.Style) {
+	groupItems := group.snapshots(items)
+	status := rollUpStatus(groupItems)
+	completed, total, _ := progressOf(groupItems)
+	return fmt.Sprintf("%s  %s [%d/%d]", statusIcon(status), group.Name, completed, total), ui.statusStyle(status)
+}
+
+// itemLine returns the line of a check item.
+func (ui *UIRenderer) itemLine(item CheckSnapshot) (string, tcell.Style) {
+	status := item.Status
+	name := item.Name
+	subProgress := item.SubProgress
+	subMessage := item.SubMessage
+	err := item.Error
+	attempt := item.Attempt
+	maxAttempts := item.MaxAttempts
+
+	icon := statusIcon(status)
+	style := ui.statusStyle(status)
+	var line string
+	switch status {
+	case StatusFailed, StatusCancelled, StatusSkipped, StatusWarning:
+		errMsg := ""
+		if err != nil {
+			errMsg = fmt.Sprintf(" (%s)", err.Error())
+		}
+		line = fmt.Sprintf("%s  %s%s", icon, name, errMsg)
+	case StatusAborted:
+		line = fmt.Sprintf("%s  %s (aborted)", icon, name)
+	case StatusInProgress:
+		progressText := fmt.Sprintf("%d%%", subProgress)
+		if subMessage != "" {
+			progressText = fmt.Sprintf("%d%% - %s", subProgress, subMessage)
+		}
+		if attempt > 1 {
+			progressText = fmt.Sprintf("%s, attempt %d/%d", progressText, attempt, maxAttempts)
+		}
+		line = fmt.Sprintf("%s  %s (%s)", icon, name, progressText)
+	default:
+		line = fmt.Sprintf("%s  %s", icon, name)
+	}
+
+	// Elapsed time of running checks, final duration of finished ones
+	if !item.StartedAt.IsZero() {
+		line = fmt.Sprintf("%s [%s]", line, formatDuration(item.Duration()))
+	}
+	return line, style
+}
+
+// formatDuration formats d with a precision suitable for the check list.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
 // Draw renders the entire UI.
 func (ui *UIRenderer) Draw() {
 	ui.mu.Lock()
@@ -102,25 +266,26 @@ func (ui *UIRenderer) Draw() {
 		return
 	}
 
-	items := ui.manager.GetItems()
-	numItems := len(items)
+	items := ui.manager.Snapshot() // The state drawn in this frame
+	rows := layoutRows(items)
+	numRows := len(rows)
 	displayableRows := height - 1
 
 	// Check if all tasks are completed
 	allCompleted := true
+	ui.running = false
 	for _, item := range items {
-		item.mu.Lock()
 		status := item.Status
-		item.mu.Unlock()
-
 		if status == StatusInProgress || status == StatusPending {
 			allCompleted = false
-			break
+		}
+		if status == StatusInProgress {
+			ui.running = true
 		}
 	}
 
 	// Auto-close if all checks are completed
-	if allCompleted {
+	if allCompleted && ui.AutoQuit {
 		go func() {
 			// Small delay to show final state briefly
 			select {
@@ -134,66 +299,62 @@ func (ui *UIRenderer) Draw() {
 		}()
 	}
 
-	// Handle scrolling
-	if ui.scrollTop > 0 && ui.scrollTop >= numItems-displayableRows+1 && numItems > displayableRows {
-		ui.scrollTop = max(numItems-displayableRows, 0)
+	if ui.detail != 0 {
+		for _, item := range items {
+			if item.ID == ui.detail {
+				ui.drawDetail(item, width, height)
+				ui.screen.Show()
+				return
+			}
+		}
+		ui.detail = 0 // The check no longer exists
+	}
+
+	// Handle scrolling, keeping the selected row visible
+	ui.selected = min(ui.selected, max(numRows-1, 0))
+	if ui.selected < ui.scrollTop {
+		ui.scrollTop = ui.selected
+	}
+	if ui.selected >= ui.scrollTop+displayableRows {
+		ui.scrollTop = ui.selected - displayableRows + 1
+	}
+	if ui.scrollTop > 0 && ui.scrollTop >= numRows-displayableRows+1 && numRows > displayableRows {
+		ui.scrollTop = max(numRows-displayableRows, 0)
 	}
 
-	// Draw items
+	// Draw items below their group headers
 	y := 0
-	for i := ui.scrollTop; i < numItems && y < displayableRows; i++ {
-		item := items[i]
-		item.mu.Lock()
-		status := item.Status
-		name := item.Name
-		subProgress := item.SubProgress
-		subMessage := item.SubMessage
-		err := item.Error
-		item.mu.Unlock()
-
+	for i := ui.scrollTop; i < numRows && y < displayableRows; i++ {
+		r := rows[i]
 		var line string
-		style := ui.StyleDefault
-
-		switch status {
-		case StatusCompleted:
-			style = ui.StyleGood
-			line = fmt.Sprintf("✅  %s", name)
-		case StatusFailed:
-			style = ui.StyleBad
-			errMsg := ""
-			if err != nil {
-				errMsg = fmt.Sprintf(" (%s)", err.Error())
-			}
-			line = fmt.Sprintf("❌  %s%s", name, errMsg)
-		case StatusInProgress:
-			style = ui.StyleWarning
-			progressText := fmt.Sprintf("%d%%", subProgress)
-			if subMessage != "" {
-				progressText = fmt.Sprintf("%d%% - %s", subProgress, subMessage)
-			}
-			line = fmt.Sprintf("⏳  %s (%s)", name, progressText)
-		case StatusPending:
-			line = fmt.Sprintf("-  %s", name)
+		var style tcell.Style
+		if r.group != nil {
+			line, style = ui.groupLine(r.group, items)
+		} else {
+			line, style = ui.itemLine(*r.item)
+		}
+		if i == ui.selected {
+			style = style.Reverse(true)
 		}
-		ui.emitStr(0, y, style, line)
+		ui.emitStr(2*r.depth, y, style, line)
 		y++
 	}
 
 	// Draw scroll indicators if necessary
-	if displayableRows < numItems {
+	if displayableRows < numRows {
 		if ui.scrollTop > 0 {
 			ui.emitStr(width-1, 0, ui.StyleScrollBarArrow, "▲")
 		}
-		if ui.scrollTop+displayableRows < numItems {
+		if ui.scrollTop+displayableRows < numRows {
 			ui.emitStr(width-1, displayableRows-1, ui.StyleScrollBarArrow, "▼")
 		}
 	}
 
 	// Draw scroll bar
-	ui.drawScrollBar(width, height, numItems, displayableRows)
+	ui.drawScrollBar(width, height, numRows, displayableRows)
 
 	// Draw overall progress bar at the bottom
-	completed, total, overallProgress := ui.manager.CalculateOverallProgress()
+	completed, total, overallProgress := progressOf(items)
 	progressText := fmt.Sprintf("Overall Progress: %d/%d (%d%%)", completed, total, overallProgress)
 	barWidth := width - 2 // for borders [ and ]
 	filledWidth := (barWidth * overallProgress) / 100
@@ -219,7 +380,104 @@ func (ui *UIRenderer) Draw() {
 	ui.screen.Show()
 }
 
+// detailLines returns the lines of the detail view of a check.
+func detailLines(item CheckSnapshot) []string {
+	name := item.Name
+	status := item.Status
+	err := item.Error
+	attempt := item.Attempt
+	maxAttempts := item.MaxAttempts
+
+	lines := []string{
+		name,
+		fmt.Sprintf("Status:  %s", status),
+		fmt.Sprintf("Attempt: %d/%d", attempt, maxAttempts),
+	}
+	if !item.StartedAt.IsZero() {
+		lines = append(lines, fmt.Sprintf("Time:    %s", formatDuration(item.Duration())))
+	}
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("Error:   %s", err))
+	}
+	if len(item.Logs) > 0 {
+		lines = append(lines, "", "Log:")
+		if item.LogsDropped > 0 {
+			lines = append(lines, fmt.Sprintf("(%d older entries dropped)", item.LogsDropped))
+		}
+		for _, entry := range item.Logs {
+			lines = append(lines, entry.String())
+		}
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		lines = append(lines, "", "Stack:")
+		for _, line := range strings.Split(strings.TrimRight(string(panicErr.Stack), "\n"), "\n") {
+			lines = append(lines, strings.ReplaceAll(line, "\t", "    "))
+		}
+	}
+	return lines
+}
+
+// drawDetail draws the detail view of item. ui.mu must be held.
+func (ui *UIRenderer) drawDetail(item CheckSnapshot, width, height int) {
+	lines := detailLines(item)
+	displayableRows := height - 1
+	ui.detailTop = max(min(ui.detailTop, len(lines)-displayableRows), 0)
+
+	for y := 0; y < displayableRows && ui.detailTop+y < len(lines); y++ {
+		style := ui.StyleDefault
+		if ui.detailTop+y == 0 {
+			_, style = ui.itemLine(item)
+		}
+		ui.emitStr(0, y, style, lines[ui.detailTop+y])
+	}
+	ui.drawScrollBar(width, height, len(lines), displayableRows)
+
+	help := "Up/Down: scroll, Esc: back"
+	for i := range width {
+		ui.screen.SetContent(i, height-1, ' ', nil, ui.StyleProgress)
+	}
+	ui.emitStr(0, height-1, ui.StyleProgress, help)
+}
+
+// handleDetailKey handles a key press while the detail view is open and
+// reports whether it was consumed.
+func (ui *UIRenderer) handleDetailKey(ev *tcell.EventKey) bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.detail == 0 {
+		return false
+	}
+	switch {
+	case ev.Key() == tcell.KeyUp:
+		ui.detailTop = max(ui.detailTop-1, 0)
+	case ev.Key() == tcell.KeyDown:
+		ui.detailTop++ // Clamped when drawing
+	case ev.Key() == tcell.KeyEscape, ev.Key() == tcell.KeyLeft, ev.Key() == tcell.KeyRune && (ev.Rune() == 'q' || ev.Rune() == 'd'):
+		ui.detail = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// selectedItem returns the check in the selected row, or nil if a group header is selected.
+func (ui *UIRenderer) selectedItem() *CheckSnapshot {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	rows := layoutRows(ui.manager.Snapshot())
+	if ui.selected < len(rows) {
+		return rows[ui.selected].item
+	}
+	return nil
+}
+
 // Run a loop to handle key presses and window resizing.
+// Up and Down select a row, "r" re-runs the selected check and "R" re-runs
+// all failed checks. Right or "d" open the detail view of the selected check,
+// showing e.g. the stack of a panic, and Left or Esc close it again.
+// Once all checks are done, Enter, Esc or "q" quit;
+// Ctrl+C quits at any time and aborts the checks still running.
 func (ui *UIRenderer) Run() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -250,7 +508,26 @@ func (ui *UIRenderer) Run() {
 					ui.mu.Unlock()
 					ui.Draw()
 				case *tcell.EventKey:
-					if ev.Key() == tcell.KeyEscape || ev.Key() == tcell.KeyCtrlC || ev.Key() == tcell.KeyEnter || (ev.Key() == tcell.KeyRune && ev.Rune() == 'q') {
+					if ev.Key() == tcell.KeyCtrlC {
+						// Abort any running checks and leave immediately
+						ui.Stop()
+						return
+					}
+					if ui.handleDetailKey(ev) {
+						ui.Draw()
+						continue
+					}
+					if ev.Key() == tcell.KeyRight || (ev.Key() == tcell.KeyRune && ev.Rune() == 'd') {
+						// Open the detail view of the selected check
+						if item := ui.selectedItem(); item != nil {
+							ui.mu.Lock()
+							ui.detail = item.ID
+							ui.detailTop = 0
+							ui.mu.Unlock()
+						}
+						ui.Draw()
+					}
+					if ev.Key() == tcell.KeyEscape || ev.Key() == tcell.KeyEnter || (ev.Key() == tcell.KeyRune && ev.Rune() == 'q') {
 						completedCnt, totalCnt, _ := ui.manager.CalculateOverallProgress()
 						if completedCnt == totalCnt {
 							ui.quitOnce.Do(func() {
@@ -261,37 +538,85 @@ func (ui *UIRenderer) Run() {
 					}
 					if ev.Key() == tcell.KeyDown {
 						ui.mu.Lock()
-						itemsCount := len(ui.manager.GetItems())
-						_, h := ui.screen.Size()
-						displayableRows := h - 1
-						if ui.scrollTop < itemsCount-displayableRows {
-							ui.scrollTop++
+						rowsCount := len(layoutRows(ui.manager.Snapshot()))
+						if ui.selected < rowsCount-1 {
+							ui.selected++
 						}
 						ui.mu.Unlock()
 						ui.Draw()
 					}
 					if ev.Key() == tcell.KeyUp {
 						ui.mu.Lock()
-						if ui.scrollTop > 0 {
-							ui.scrollTop--
+						if ui.selected > 0 {
+							ui.selected--
 						}
 						ui.mu.Unlock()
 						ui.Draw()
 					}
+					if ev.Key() == tcell.KeyRune && ev.Rune() == 'r' {
+						// Re-run the selected check
+						if item := ui.selectedItem(); item != nil {
+							ui.manager.RerunItem(item.ID)
+						}
+						ui.Draw()
+					}
+					if ev.Key() == tcell.KeyRune && ev.Rune() == 'R' {
+						// Re-run all failed checks
+						ui.manager.RerunFailed()
+						ui.Draw()
+					}
 				}
 			}
 		}
 	}()
 
-	// Redraw loop (triggered by CheckManager or periodically)
-	// The CheckManager's uiUpdate callback will call ui.Draw()
-	// We also need this loop to handle the quit signal correctly.
+	// Redraw loop, triggered by changes of the checks
+	go ui.redrawLoop()
+
 	<-ui.quit
 }
 
-// Stop cleanly shuts down the UI event loop.
+// redrawLoop redraws the UI whenever the state of the checks changed, at most
+// MaxFPS times per second. Changes made between two frames are coalesced.
+// While checks are running, it also redraws periodically to update their
+// elapsed time.
+func (ui *UIRenderer) redrawLoop() {
+	interval := time.Second / time.Duration(max(ui.MaxFPS, 1))
+	ticker := time.NewTicker(max(interval, elapsedRefreshInterval))
+	defer ticker.Stop()
+	var lastDraw time.Time
+	for {
+		select {
+		case <-ui.quit:
+			return
+		case <-ui.manager.Changes():
+		case <-ticker.C:
+			ui.mu.Lock()
+			running := ui.running
+			ui.mu.Unlock()
+			if !running {
+				continue
+			}
+		}
+
+		if wait := interval - time.Since(lastDraw); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ui.quit:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		lastDraw = time.Now()
+		ui.Draw()
+	}
+}
+
+// Stop cleanly shuts down the UI event loop and aborts checks that are still running.
 func (ui *UIRenderer) Stop() {
 	ui.quitOnce.Do(func() {
 		close(ui.quit)
 	})
+	ui.manager.Stop()
 }
@@ -0,0 +1,100 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownCheck is returned when no check has the requested ID.
+var ErrUnknownCheck = errors.New("unknown check")
+
+// ResetItem moves a finished check back to StatusPending, so the next run
+// executes it again. Checks that are still pending or running cannot be reset.
+func (cm *CheckManager) ResetItem(id int) error {
+	item := cm.getItem(id)
+	if item == nil {
+		return fmt.Errorf("%w: #%d", ErrUnknownCheck, id)
+	}
+	if !item.reset() {
+		return fmt.Errorf("check %q cannot be reset while it is %s", item.Name, item.status())
+	}
+	return nil
+}
+
+// RerunItem resets a finished check and executes it again, in the run in
+// progress if there is one, otherwise in a new run.
+func (cm *CheckManager) RerunItem(id int) (*RunHandle, error) {
+	if err := cm.ResetItem(id); err != nil {
+		return nil, err
+	}
+	return cm.runReset(nil), nil
+}
+
+// RerunFailed resets and executes again all checks that failed, were
+// cancelled or aborted, or were skipped because a dependency failed.
+func (cm *CheckManager) RerunFailed() *RunHandle {
+	return cm.runReset(func(item *CheckItem) bool {
+		switch item.Status {
+		case StatusFailed, StatusCancelled, StatusAborted:
+			return true
+		case StatusSkipped:
+			return errors.Is(item.Error, ErrDependencyFailed)
+		default:
+			return false
+		}
+	})
+}
+
+// RerunAll resets and executes again all finished checks.
+func (cm *CheckManager) RerunAll() *RunHandle {
+	return cm.runReset(func(*CheckItem) bool { return true })
+}
+
+// getItem returns the item with the given ID, or nil.
+func (cm *CheckManager) getItem(id int) *CheckItem {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	for _, item := range cm.items {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// runReset resets the finished items matching the predicate, if any, and
+// executes the pending items in the latest run in progress or in a new run.
+func (cm *CheckManager) runReset(match func(*CheckItem) bool) *RunHandle {
+	cm.mu.Lock()
+	var run *RunHandle
+	if len(cm.runs) > 0 {
+		run = cm.runs[len(cm.runs)-1]
+	}
+	for _, item := range cm.items {
+		if match != nil {
+			item.mu.Lock()
+			matched := item.Status.isDone() && match(item)
+			item.mu.Unlock()
+			if !matched || !item.reset() {
+				continue
+			}
+		}
+		if run == nil {
+			continue
+		}
+		item.mu.Lock()
+		isPending := item.Status == StatusPending && !item.claimed
+		item.mu.Unlock()
+		if isPending {
+			cm.enqueueLocked(run, item)
+		}
+	}
+	cm.mu.Unlock()
+	cm.markDirty()
+
+	if run != nil {
+		return run
+	}
+	return cm.Start(context.Background())
+}
@@ -0,0 +1,91 @@
+package tcheck
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResetItem(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	id, _ := cm.AddCheck("check", func(SubProgressReporter) error { return errors.New("unplugged") })
+
+	if err := cm.ResetItem(id); err == nil {
+		t.Error("Expected an error when resetting a pending check")
+	}
+	if err := cm.ResetItem(42); !errors.Is(err, ErrUnknownCheck) {
+		t.Errorf("Expected ErrUnknownCheck, got %v", err)
+	}
+
+	cm.RunAllChecks().Wait()
+	if err := cm.ResetItem(id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	item := cm.GetItems()[0]
+	item.mu.Lock()
+	defer item.mu.Unlock()
+	if item.Status != StatusPending || item.Error != nil || item.Attempt != 0 {
+		t.Errorf("Expected a clean pending check, got %v (%v), attempt %d", item.Status, item.Error, item.Attempt)
+	}
+}
+
+func TestRerunItem(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	var plugged atomic.Bool
+	id, _ := cm.AddCheck("cable", func(SubProgressReporter) error {
+		if !plugged.Load() {
+			return errors.New("unplugged")
+		}
+		return nil
+	})
+
+	if summary := cm.RunAllChecks().Wait(); summary.Counts[StatusFailed] != 1 {
+		t.Fatalf("Expected the first run to fail, got %v", summary.Counts)
+	}
+
+	plugged.Store(true)
+	run, err := cm.RerunItem(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary := run.Wait(); summary.Total != 1 || summary.Counts[StatusCompleted] != 1 {
+		t.Errorf("Expected the re-run to succeed, got %v", summary.Counts)
+	}
+}
+
+func TestRerunFailed(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+
+	var calls atomic.Int32
+	var fixed atomic.Bool
+	cm.AddCheck("ok", func(SubProgressReporter) error {
+		calls.Add(1)
+		return nil
+	})
+	cm.AddCheck("db", func(SubProgressReporter) error {
+		if !fixed.Load() {
+			return errors.New("db down")
+		}
+		return nil
+	})
+	cm.AddCheck("schema", func(SubProgressReporter) error { return nil }, DependsOn("db"))
+
+	if summary := cm.RunAllChecks().Wait(); summary.Counts[StatusSkipped] != 1 {
+		t.Fatalf("Expected the dependent check to be skipped, got %v", summary.Counts)
+	}
+
+	fixed.Store(true)
+	summary := cm.RerunFailed().Wait()
+	if summary.Total != 2 || summary.Counts[StatusCompleted] != 2 {
+		t.Errorf("Expected the failed and skipped checks to be re-run, got %+v", summary)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected successful checks not to be re-run, got %d calls", calls.Load())
+	}
+
+	if summary := cm.RerunAll().Wait(); summary.Total != 3 {
+		t.Errorf("Expected all checks to be re-run, got %d", summary.Total)
+	}
+}
@@ -0,0 +1,93 @@
+package tcheck
+
+import "slices"
+
+// UsesResources declares named resources the check needs while it runs, e.g.
+// "package-manager" or "network". The check is only started once all of them
+// are available at the same time, within the limits set with WithResourceLimit.
+// Each resource counts once per check, even if it is named several times.
+func UsesResources(names ...string) CheckOption {
+	return func(ci *CheckItem) {
+		for _, name := range names {
+			if !slices.Contains(ci.resources, name) {
+				ci.resources = append(ci.resources, name)
+			}
+		}
+	}
+}
+
+// WithResourceLimit sets how many checks may use the named resource at the
+// same time. Resources without a limit are exclusive, i.e. have a limit of 1.
+// Resource limits apply in addition to maxConcurrentChecks.
+func WithResourceLimit(name string, limit int) ManagerOption {
+	return func(cm *CheckManager) {
+		cm.resourceLimits[name] = max(limit, 1)
+	}
+}
+
+// resourcesAvailable reports whether all resources of the item are currently available.
+func (cm *CheckManager) resourcesAvailable(item *CheckItem) bool {
+	if len(item.resources) == 0 {
+		return true
+	}
+
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.resourcesAvailableLocked(item)
+}
+
+// resourcesAvailableLocked is like resourcesAvailable. cm.mu must be held.
+func (cm *CheckManager) resourcesAvailableLocked(item *CheckItem) bool {
+	for _, name := range item.resources {
+		limit, ok := cm.resourceLimits[name]
+		if !ok {
+			limit = 1
+		}
+		if cm.resourceUse[name] >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+// tryAcquireResources takes all resources of the item, or none of them if one
+// is not available, so checks needing several resources cannot deadlock.
+func (cm *CheckManager) tryAcquireResources(item *CheckItem) bool {
+	if len(item.resources) == 0 {
+		return true
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if !cm.resourcesAvailableLocked(item) {
+		return false
+	}
+	for _, name := range item.resources {
+		cm.resourceUse[name]++
+	}
+	return true
+}
+
+// releaseResources gives back the resources of the item and wakes up runs
+// waiting for them.
+func (cm *CheckManager) releaseResources(item *CheckItem) {
+	if len(item.resources) == 0 {
+		return
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for _, name := range item.resources {
+		cm.resourceUse[name]--
+	}
+	close(cm.resourcesReleased)
+	cm.resourcesReleased = make(chan struct{})
+}
+
+// resourcesReleasedChan returns a channel that is closed the next time
+// resources are released.
+func (cm *CheckManager) resourcesReleasedChan() <-chan struct{} {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.resourcesReleased
+}
@@ -0,0 +1,112 @@
+package tcheck
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// concurrencyTracker records the highest number of checks running at once per resource.
+type concurrencyTracker struct {
+	mu      sync.Mutex
+	running map[string]int
+	peak    map[string]int
+}
+
+func newConcurrencyTracker() *concurrencyTracker {
+	return &concurrencyTracker{running: make(map[string]int), peak: make(map[string]int)}
+}
+
+func (ct *concurrencyTracker) check(resources ...string) CheckFunc {
+	return func(SubProgressReporter) error {
+		ct.mu.Lock()
+		for _, name := range resources {
+			ct.running[name]++
+			ct.peak[name] = max(ct.peak[name], ct.running[name])
+		}
+		ct.mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		ct.mu.Lock()
+		for _, name := range resources {
+			ct.running[name]--
+		}
+		ct.mu.Unlock()
+		return nil
+	}
+}
+
+func TestResourceLimits(t *testing.T) {
+	cm := NewCheckManager(nil, 8, WithResourceLimit("network", 3))
+	ct := newConcurrencyTracker()
+
+	for range 4 {
+		cm.AddCheck("package", ct.check("pkg", "all"), UsesResources("pkg"))
+	}
+	for range 6 {
+		cm.AddCheck("ping", ct.check("network", "all"), UsesResources("network"))
+	}
+
+	summary := cm.RunAllChecks().Wait()
+	if summary.Counts[StatusCompleted] != 10 {
+		t.Fatalf("Expected all checks to complete, got %v", summary.Counts)
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if ct.peak["pkg"] != 1 {
+		t.Errorf("Expected exclusive access to 'pkg', got %d concurrent checks", ct.peak["pkg"])
+	}
+	if ct.peak["network"] > 3 {
+		t.Errorf("Expected at most 3 concurrent 'network' checks, got %d", ct.peak["network"])
+	}
+	if ct.peak["all"] < 2 {
+		t.Errorf("Expected checks on different resources to run in parallel, got %d", ct.peak["all"])
+	}
+}
+
+func TestResourceLimitsMultipleResources(t *testing.T) {
+	cm := NewCheckManager(nil, 4)
+	ct := newConcurrencyTracker()
+
+	cm.AddCheck("both", ct.check("a", "b"), UsesResources("a", "b"))
+	cm.AddCheck("a", ct.check("a"), UsesResources("a"))
+	cm.AddCheck("b", ct.check("b"), UsesResources("b"))
+	cm.AddCheck("both again", ct.check("b", "a"), UsesResources("b", "a"))
+
+	done := make(chan Summary)
+	go func() { done <- cm.RunAllChecks().Wait() }()
+
+	select {
+	case summary := <-done:
+		if summary.Counts[StatusCompleted] != 4 {
+			t.Errorf("Expected all checks to complete, got %v", summary.Counts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run deadlocked on resources")
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if ct.peak["a"] != 1 || ct.peak["b"] != 1 {
+		t.Errorf("Expected exclusive resources, got peaks %v", ct.peak)
+	}
+}
+
+func TestUsesResourcesDuplicateNames(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+	cm.AddCheck("twice", testFunc, UsesResources("x", "x"), UsesResources("x"))
+
+	done := make(chan Summary)
+	go func() { done <- cm.RunAllChecks().Wait() }()
+
+	select {
+	case summary := <-done:
+		if summary.Counts[StatusCompleted] != 1 {
+			t.Errorf("Expected the check to complete, got %v", summary.Counts)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Check naming a resource twice never started")
+	}
+}
@@ -0,0 +1,51 @@
+package tcheck
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy describes how a failed check is retried.
+type RetryPolicy struct {
+	MaxAttempts int              // Total number of attempts, including the first one
+	Backoff     time.Duration    // Delay before the second attempt
+	Exponential bool             // Double the delay after every failed attempt
+	MaxBackoff  time.Duration    // Upper bound for the delay, 0 means no limit
+	Jitter      float64          // Randomize each delay by up to this fraction of it (0-1)
+	RetryIf     func(error) bool // Only retry errors it returns true for, nil retries every error
+}
+
+// WithRetry retries the check according to the given policy when it fails.
+// Timeouts set with WithTimeout apply to every attempt separately.
+func WithRetry(policy RetryPolicy) CheckOption {
+	return func(ci *CheckItem) {
+		ci.retry = policy
+		ci.MaxAttempts = max(policy.MaxAttempts, 1)
+	}
+}
+
+// shouldRetry reports whether err is eligible for another attempt.
+func (p RetryPolicy) shouldRetry(err error) bool {
+	return p.RetryIf == nil || p.RetryIf(err)
+}
+
+// delay returns how long to wait after the given failed attempt (1-based).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	if p.Exponential {
+		for i := 1; i < attempt; i++ {
+			d *= 2
+			if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+				break
+			}
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		jitter := min(p.Jitter, 1)
+		d += time.Duration(float64(d) * jitter * (rand.Float64()*2 - 1))
+	}
+	return max(d, 0)
+}
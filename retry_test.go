@@ -0,0 +1,88 @@
+package tcheck
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	fixed := RetryPolicy{Backoff: 10 * time.Millisecond}
+	for attempt := 1; attempt <= 3; attempt++ {
+		if d := fixed.delay(attempt); d != 10*time.Millisecond {
+			t.Errorf("fixed: expected 10ms after attempt %d, got %v", attempt, d)
+		}
+	}
+
+	exp := RetryPolicy{Backoff: 10 * time.Millisecond, Exponential: true, MaxBackoff: 30 * time.Millisecond}
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond, 30 * time.Millisecond}
+	for i, want := range expected {
+		if d := exp.delay(i + 1); d != want {
+			t.Errorf("exponential: expected %v after attempt %d, got %v", want, i+1, d)
+		}
+	}
+
+	jitter := RetryPolicy{Backoff: 100 * time.Millisecond, Jitter: 0.5}
+	for range 20 {
+		if d := jitter.delay(1); d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Errorf("jitter: expected delay within 50-150ms, got %v", d)
+		}
+	}
+}
+
+func TestCheckItem_Retry_SucceedsEventually(t *testing.T) {
+	calls := 0
+	fn := func(r SubProgressReporter) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}
+	item := NewCheckItem(1, "flaky", fn, WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}))
+	item.Run()
+
+	if item.Status != StatusCompleted {
+		t.Errorf("expected StatusCompleted, got %v (%v)", item.Status, item.Error)
+	}
+	if calls != 3 || item.Attempt != 3 {
+		t.Errorf("expected 3 attempts, got %d calls and Attempt %d", calls, item.Attempt)
+	}
+}
+
+func TestCheckItem_Retry_GivesUp(t *testing.T) {
+	calls := 0
+	expectedErr := errors.New("still broken")
+	fn := func(r SubProgressReporter) error {
+		calls++
+		return expectedErr
+	}
+	item := NewCheckItem(2, "broken", fn, WithRetry(RetryPolicy{MaxAttempts: 2}))
+	item.Run()
+
+	if item.Status != StatusFailed || item.Error != expectedErr {
+		t.Errorf("expected StatusFailed with %v, got %v (%v)", expectedErr, item.Status, item.Error)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestCheckItem_Retry_RetryIf(t *testing.T) {
+	permanent := errors.New("permanent")
+	calls := 0
+	fn := func(r SubProgressReporter) error {
+		calls++
+		return permanent
+	}
+	policy := RetryPolicy{
+		MaxAttempts: 5,
+		RetryIf:     func(err error) bool { return !errors.Is(err, permanent) },
+	}
+	item := NewCheckItem(3, "permanent", fn, WithRetry(policy))
+	item.Run()
+
+	if calls != 1 {
+		t.Errorf("expected no retries for a permanent error, got %d calls", calls)
+	}
+}
@@ -0,0 +1,140 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RunHandle tracks a run started by CheckManager.RunAllChecks or CheckManager.Start.
+type RunHandle struct {
+	cancel         context.CancelCauseFunc
+	wg             sync.WaitGroup // Tracks the dispatched checks
+	added          []*CheckItem   // Items not yet taken over by the dispatcher, guarded by CheckManager.mu
+	wake           chan struct{}  // Notifies the dispatcher about added items
+	dispatched     chan struct{}  // Closed once no claimed item is waiting to be started
+	dispatchedOnce sync.Once
+	done           chan struct{}
+	started        time.Time
+	mu             sync.Mutex
+	items          []*CheckItem // Items scheduled by this run
+	finished       time.Time
+}
+
+// Failure describes a check that did not succeed, or that completed with a warning.
+type Failure struct {
+	ID     int
+	Name   string
+	Status CheckStatus // StatusFailed, StatusCancelled or StatusWarning
+	Err    error
+	Logs   []LogEntry // Latest log entries of the check
+}
+
+// Summary describes the outcome of a run.
+type Summary struct {
+	Total    int                 // Number of checks in the run
+	Counts   map[CheckStatus]int // Number of checks per status
+	Duration time.Duration       // Wall time of the run so far
+	Failures []Failure           // Checks that failed or were cancelled, in order; skipped and aborted checks are only counted
+	Warnings []Failure           // Checks that completed with a warning, in order; they are not failures
+	Err      error               // All failures joined with errors.Join, nil if there are none
+}
+
+func newRunHandle(cancel context.CancelCauseFunc) *RunHandle {
+	return &RunHandle{
+		cancel:     cancel,
+		wake:       make(chan struct{}, 1),
+		dispatched: make(chan struct{}),
+		done:       make(chan struct{}),
+		started:    time.Now(),
+	}
+}
+
+// Done returns a channel that is closed once every check of the run has finished.
+func (r *RunHandle) Done() <-chan struct{} {
+	return r.done
+}
+
+// Wait blocks until the run has finished and returns its summary.
+func (r *RunHandle) Wait() Summary {
+	<-r.done
+	return r.Summary()
+}
+
+// Cancel aborts the run: checks in progress receive a cancelled context and
+// checks not started yet are marked as StatusCancelled.
+func (r *RunHandle) Cancel() {
+	r.cancel(nil)
+}
+
+// Items returns the checks scheduled by the run, including the ones added
+// while it is in progress.
+func (r *RunHandle) Items() []*CheckItem {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	itemsCopy := make([]*CheckItem, len(r.items))
+	copy(itemsCopy, r.items)
+	return itemsCopy
+}
+
+// Summary returns the summary of the run, which is final once Done is closed.
+func (r *RunHandle) Summary() Summary {
+	r.mu.Lock()
+	end := r.finished
+	r.mu.Unlock()
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	summary := summarize(snapshotAll(r.Items()))
+	summary.Duration = end.Sub(r.started)
+	return summary
+}
+
+// markDispatched signals that no claimed item is waiting to be started anymore.
+func (r *RunHandle) markDispatched() {
+	r.dispatchedOnce.Do(func() {
+		close(r.dispatched)
+	})
+}
+
+// finish records the end of the run and closes the done channel.
+func (r *RunHandle) finish() {
+	r.mu.Lock()
+	r.finished = time.Now()
+	r.mu.Unlock()
+	close(r.done)
+}
+
+// summarize counts the statuses of items and collects their failures.
+func summarize(items []CheckSnapshot) Summary {
+	summary := Summary{
+		Total:  len(items),
+		Counts: make(map[CheckStatus]int),
+	}
+
+	var errs []error
+	for _, item := range items {
+		status := item.Status
+		err := item.Error
+
+		summary.Counts[status]++
+		if status == StatusWarning {
+			summary.Warnings = append(summary.Warnings, Failure{ID: item.ID, Name: item.Name, Status: status, Err: err, Logs: item.Logs})
+			continue
+		}
+		if status != StatusFailed && status != StatusCancelled {
+			continue
+		}
+		if err == nil {
+			err = errors.New(status.String())
+		}
+		summary.Failures = append(summary.Failures, Failure{ID: item.ID, Name: item.Name, Status: status, Err: err, Logs: item.Logs})
+		errs = append(errs, fmt.Errorf("%s: %w", item.Name, err))
+	}
+	summary.Err = errors.Join(errs...)
+	return summary
+}
@@ -0,0 +1,111 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunHandle_WaitSummary(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+
+	expectedErr := errors.New("boom")
+	cm.AddCheck("ok", testFunc)
+	cm.AddCheck("fail", func(SubProgressReporter) error { return expectedErr })
+	cm.AddCheck("dependent", testFunc, DependsOn("fail"))
+
+	run := cm.Start(context.Background())
+	summary := run.Wait()
+
+	select {
+	case <-run.Done():
+	default:
+		t.Error("Done channel should be closed after Wait returns")
+	}
+
+	if summary.Total != 3 {
+		t.Errorf("Expected 3 checks, got %d", summary.Total)
+	}
+	if summary.Counts[StatusCompleted] != 1 || summary.Counts[StatusFailed] != 1 || summary.Counts[StatusSkipped] != 1 {
+		t.Errorf("Unexpected counts %v", summary.Counts)
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0].Name != "fail" {
+		t.Errorf("Expected a single failure of 'fail', got %+v", summary.Failures)
+	}
+	if !errors.Is(summary.Err, expectedErr) {
+		t.Errorf("Expected joined error to wrap %v, got %v", expectedErr, summary.Err)
+	}
+	if summary.Duration <= 0 {
+		t.Errorf("Expected a positive duration, got %v", summary.Duration)
+	}
+}
+
+func TestRunHandle_Cancel(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	started := make(chan struct{})
+	cm.AddCheckContext("blocking", func(ctx context.Context, reporter SubProgressReporter) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cm.AddCheck("queued", testFunc)
+
+	run := cm.Start(context.Background())
+	<-started
+	run.Cancel()
+
+	select {
+	case <-run.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Run did not finish after Cancel")
+	}
+
+	summary := run.Summary()
+	if summary.Counts[StatusCancelled] != 2 || len(summary.Failures) != 2 {
+		t.Errorf("Expected both checks to be cancelled, got %v", summary.Counts)
+	}
+}
+
+func TestRunHandle_EmptyRun(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	summary := cm.RunAllChecks().Wait()
+	if summary.Total != 0 || summary.Err != nil {
+		t.Errorf("Expected an empty successful summary, got %+v", summary)
+	}
+}
+
+func TestRunHandle_SkippedNotFailure(t *testing.T) {
+	cm := NewCheckManager(nil, 1, WithFailFast())
+
+	cm.AddCheck("skipped", func(SubProgressReporter) error { return Skip("not applicable") }, Required())
+	cm.AddCheck("after", testFunc)
+
+	summary := cm.RunAllChecks().Wait()
+	if summary.Counts[StatusSkipped] != 1 || summary.Counts[StatusCompleted] != 1 {
+		t.Errorf("Unexpected counts %v", summary.Counts)
+	}
+	if len(summary.Failures) != 0 || summary.Err != nil {
+		t.Errorf("Expected no failures, got %+v", summary.Failures)
+	}
+}
+
+func TestRunHandle_Warnings(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+
+	cm.AddCheck("degraded", func(SubProgressReporter) error { return Warn(errors.New("cert expires in 20 days")) })
+	cm.AddCheck("dependent", testFunc, DependsOn("degraded"))
+
+	summary := cm.RunAllChecks().Wait()
+	if summary.Counts[StatusWarning] != 1 || summary.Counts[StatusCompleted] != 1 {
+		t.Errorf("Unexpected counts %v", summary.Counts)
+	}
+	if len(summary.Warnings) != 1 || summary.Warnings[0].Name != "degraded" {
+		t.Errorf("Expected a single warning of 'degraded', got %+v", summary.Warnings)
+	}
+	if len(summary.Failures) != 0 || summary.Err != nil {
+		t.Errorf("Expected no failures, got %+v", summary.Failures)
+	}
+}
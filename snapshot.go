@@ -0,0 +1,129 @@
+package tcheck
+
+import (
+	"slices"
+	"time"
+)
+
+// CheckSnapshot is a consistent copy of the state of a check at one point in
+// time. Unlike the fields of a *CheckItem, which are updated while the check
+// runs, it can be read freely from any goroutine.
+type CheckSnapshot struct {
+	ID          int
+	Name        string
+	Group       *CheckGroup // Group the check belongs to, nil if ungrouped
+	Severity    Severity
+	Status      CheckStatus
+	SubProgress int       // Percentage for in-progress checks (0-100)
+	SubMessage  string    // Optional message for sub-progress
+	Error       error     // Why the check failed, or was cancelled or skipped
+	Attempt     int       // Current or last attempt number, starting at 1
+	MaxAttempts int       // Number of attempts allowed by the retry policy
+	QueuedAt    time.Time // When a run claimed the check, zero if it is not queued
+	StartedAt   time.Time // When the check started running, zero if it has not started
+	FinishedAt  time.Time // When the check reached a final status, zero if it is not done
+
+	Logs        []LogEntry // Latest log entries of the check, oldest first
+	LogsDropped int        // Number of older entries dropped because of the log limit
+}
+
+// Duration returns how long the check ran: the final duration once it is
+// done, the elapsed time while it is running, and 0 if it never started.
+func (s CheckSnapshot) Duration() time.Duration {
+	switch {
+	case s.StartedAt.IsZero():
+		return 0
+	case s.FinishedAt.IsZero():
+		return time.Since(s.StartedAt)
+	default:
+		return s.FinishedAt.Sub(s.StartedAt)
+	}
+}
+
+// Snapshot returns a consistent copy of the current state of the check.
+func (ci *CheckItem) Snapshot() CheckSnapshot {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	return CheckSnapshot{
+		ID:          ci.ID,
+		Name:        ci.Name,
+		Group:       ci.Group,
+		Severity:    ci.severity,
+		Status:      ci.Status,
+		SubProgress: ci.SubProgress,
+		SubMessage:  ci.SubMessage,
+		Error:       ci.Error,
+		Attempt:     ci.Attempt,
+		MaxAttempts: ci.MaxAttempts,
+		QueuedAt:    ci.QueuedAt,
+		StartedAt:   ci.StartedAt,
+		FinishedAt:  ci.FinishedAt,
+		Logs:        slices.Clone(ci.logs),
+		LogsDropped: ci.logsDropped,
+	}
+}
+
+// Snapshot returns the current state of all checks, in the order they were added.
+func (cm *CheckManager) Snapshot() []CheckSnapshot {
+	return snapshotAll(cm.GetItems())
+}
+
+// Durations returns how long each check ran, by check ID, see CheckSnapshot.Duration.
+// Checks that never started are not included.
+func (cm *CheckManager) Durations() map[int]time.Duration {
+	durations := make(map[int]time.Duration)
+	for _, s := range cm.Snapshot() {
+		if !s.StartedAt.IsZero() {
+			durations[s.ID] = s.Duration()
+		}
+	}
+	return durations
+}
+
+// WallTime returns the time from the start of the first check to the end of
+// the last one, or until now while checks are still running. Checks are
+// counted from their latest run.
+func (cm *CheckManager) WallTime() time.Duration {
+	var first, last time.Time
+	for _, s := range cm.Snapshot() {
+		if s.StartedAt.IsZero() {
+			continue
+		}
+		end := s.FinishedAt
+		if end.IsZero() {
+			end = time.Now()
+		}
+		if first.IsZero() || s.StartedAt.Before(first) {
+			first = s.StartedAt
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+	return last.Sub(first)
+}
+
+// snapshotAll returns the snapshots of items.
+func snapshotAll(items []*CheckItem) []CheckSnapshot {
+	snapshots := make([]CheckSnapshot, len(items))
+	for i, item := range items {
+		snapshots[i] = item.Snapshot()
+	}
+	return snapshots
+}
+
+// progressOf counts the finished checks of snapshots, like
+// CheckManager.CalculateOverallProgress.
+func progressOf(snapshots []CheckSnapshot) (int, int, int) {
+	if len(snapshots) == 0 {
+		return 0, 0, 0
+	}
+
+	completedCount := 0
+	for _, s := range snapshots {
+		if s.Status.isDone() {
+			completedCount++
+		}
+	}
+	return completedCount, len(snapshots), (completedCount * 100) / len(snapshots)
+}
@@ -0,0 +1,110 @@
+package tcheck
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	cm := NewCheckManager(nil, 2)
+	group := cm.AddGroup("Group", nil)
+
+	expectedErr := errors.New("boom")
+	cm.AddCheck("ok", testFunc, InGroup(group))
+	cm.AddCheck("fail", func(SubProgressReporter) error { return expectedErr }, WithSeverity(SeverityOptional))
+
+	before := cm.Snapshot()
+	if len(before) != 2 || before[0].Status != StatusPending || before[1].Status != StatusPending {
+		t.Fatalf("Expected two pending checks, got %+v", before)
+	}
+
+	cm.RunAllChecks().Wait()
+
+	// Snapshots are copies that do not change anymore
+	if before[0].Status != StatusPending {
+		t.Error("Expected a snapshot to keep its state")
+	}
+
+	after := cm.Snapshot()
+	if after[0].ID != 1 || after[0].Name != "ok" || after[0].Group != group || after[0].Status != StatusCompleted || after[0].SubProgress != 100 {
+		t.Errorf("Unexpected snapshot %+v", after[0])
+	}
+	if after[1].Status != StatusFailed || !errors.Is(after[1].Error, expectedErr) || after[1].Severity != SeverityOptional || after[1].Attempt != 1 || after[1].MaxAttempts != 1 {
+		t.Errorf("Unexpected snapshot %+v", after[1])
+	}
+	if item := cm.GetItems()[1]; !reflect.DeepEqual(item.Snapshot(), after[1]) {
+		t.Errorf("Expected the item snapshot to match, got %+v", item.Snapshot())
+	}
+}
+
+func TestSnapshot_ConcurrentReads(t *testing.T) {
+	cm := NewCheckManager(nil, 4)
+	for range 8 {
+		cm.AddCheck("check", func(r SubProgressReporter) error {
+			for i := range 100 {
+				r.ReportSubProgress(i, "working")
+			}
+			return nil
+		})
+	}
+
+	run := cm.Start(context.Background())
+	for {
+		select {
+		case <-run.Done():
+			for _, s := range cm.Snapshot() {
+				if s.Status != StatusCompleted {
+					t.Errorf("Expected completed checks, got %v", s.Status)
+				}
+			}
+			return
+		default:
+			for _, s := range cm.Snapshot() {
+				_ = s.Status
+				_ = s.SubProgress
+			}
+		}
+	}
+}
+
+func TestSnapshot_Timing(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	cm.AddCheck("slow", func(SubProgressReporter) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	})
+	cm.AddCheck("fast", testFunc)
+	cm.AddCheck("skipped", testFunc, DependsOn("unknown"))
+
+	if wall := cm.WallTime(); wall != 0 {
+		t.Errorf("Expected no wall time before the run, got %v", wall)
+	}
+
+	cm.RunAllChecks().Wait()
+
+	snapshots := cm.Snapshot()
+	slow, fast, skipped := snapshots[0], snapshots[1], snapshots[2]
+	if slow.QueuedAt.IsZero() || slow.StartedAt.Before(slow.QueuedAt) || slow.FinishedAt.Before(slow.StartedAt) {
+		t.Errorf("Unexpected timestamps %v, %v, %v", slow.QueuedAt, slow.StartedAt, slow.FinishedAt)
+	}
+	if slow.Duration() < 30*time.Millisecond {
+		t.Errorf("Expected a duration of at least 30ms, got %v", slow.Duration())
+	}
+	if fast.StartedAt.Before(slow.FinishedAt) {
+		t.Error("Expected the second check to start after the first one with a single worker")
+	}
+	if !skipped.StartedAt.IsZero() || skipped.FinishedAt.IsZero() || skipped.Duration() != 0 {
+		t.Errorf("Expected a skipped check to finish without starting, got %+v", skipped)
+	}
+
+	durations := cm.Durations()
+	if len(durations) != 2 || durations[slow.ID] != slow.Duration() || durations[fast.ID] != fast.Duration() {
+		t.Errorf("Unexpected durations %v", durations)
+	}
+	if wall := cm.WallTime(); wall != fast.FinishedAt.Sub(slow.StartedAt) {
+		t.Errorf("Expected wall time %v, got %v", fast.FinishedAt.Sub(slow.StartedAt), wall)
+	}
+}
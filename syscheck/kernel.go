@@ -0,0 +1,146 @@
+package syscheck
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// sysctlFile returns the /proc/sys file of a sysctl name such as "vm.swappiness".
+func sysctlFile(name string) string {
+	return "proc/sys/" + strings.ReplaceAll(name, ".", "/")
+}
+
+// Sysctl returns a check that the sysctl name, e.g. "net.ipv4.ip_forward",
+// has the value expected. Whitespace within values is compared loosely, so
+// "net.ipv4.ip_local_port_range" may be expected as "32768 60999".
+func Sysctl(name, expected string, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading "+name)
+		value, err := c.readFile(sysctlFile(name))
+		if err != nil {
+			return err
+		}
+		value = strings.Join(strings.Fields(value), " ")
+		if value != strings.Join(strings.Fields(expected), " ") {
+			return fmt.Errorf("%s is %q, expected %q", name, value, expected)
+		}
+		reporter.ReportSubProgress(100, name+" = "+value)
+		return nil
+	}
+}
+
+// SysctlMin returns a check that the numeric sysctl name, e.g.
+// "fs.inotify.max_user_watches", is large enough. It fails below limit.Fail
+// and warns below limit.Warn.
+func SysctlMin(name string, limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	return sysctlLimit(name, opts, func(value float64) error {
+		return limit.checkMin(name, value, formatCount)
+	})
+}
+
+// SysctlMax returns a check that the numeric sysctl name, e.g.
+// "vm.swappiness", is small enough. It fails above limit.Fail and warns above
+// limit.Warn.
+func SysctlMax(name string, limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	return sysctlLimit(name, opts, func(value float64) error {
+		return limit.checkMax(name, value, formatCount)
+	})
+}
+
+func sysctlLimit(name string, opts []Option, check func(value float64) error) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading "+name)
+		content, err := c.readFile(sysctlFile(name))
+		if err != nil {
+			return err
+		}
+		value, err := strconv.ParseFloat(content, 64)
+		if err != nil {
+			return fmt.Errorf("%s is not a number: %q", name, content)
+		}
+		if err := check(value); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(100, name+" = "+content)
+		return nil
+	}
+}
+
+// VersionLimit is a pair of minimum versions, e.g. "5.10". Empty versions are
+// not checked.
+type VersionLimit struct {
+	Fail string // The check fails below this version
+	Warn string // The check warns below this version
+}
+
+// KernelVersion returns a check of the running kernel's release, e.g.
+// "6.1.0-13-amd64". Only the leading numeric components are compared.
+func KernelVersion(limit VersionLimit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading kernel release")
+		release, err := c.readFile("proc/sys/kernel/osrelease")
+		if err != nil {
+			return err
+		}
+		tcheck.LoggerOf(reporter).Infof("kernel release %s", release)
+
+		switch {
+		case limit.Fail != "" && compareVersions(release, limit.Fail) < 0:
+			return fmt.Errorf("kernel %s is older than %s", release, limit.Fail)
+		case limit.Warn != "" && compareVersions(release, limit.Warn) < 0:
+			return tcheck.Warnf("kernel %s is older than %s", release, limit.Warn)
+		}
+		reporter.ReportSubProgress(100, "Kernel "+release)
+		return nil
+	}
+}
+
+// compareVersions compares the leading dotted numbers of two versions,
+// returning -1, 0 or +1. Missing components count as 0.
+func compareVersions(a, b string) int {
+	va, vb := versionNumbers(a), versionNumbers(b)
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionNumbers returns the leading dotted numbers of a version, e.g.
+// [5 15 0] for "5.15.0-91-generic".
+func versionNumbers(version string) []int {
+	var numbers []int
+	for _, part := range strings.Split(version, ".") {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end == -1 {
+			end = len(part)
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			break
+		}
+		numbers = append(numbers, n)
+		if end < len(part) {
+			break
+		}
+	}
+	return numbers
+}
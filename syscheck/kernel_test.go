@@ -0,0 +1,72 @@
+package syscheck
+
+import (
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func TestSysctl(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/sys/net/ipv4/ip_forward":          "1\n",
+		"proc/sys/net/ipv4/ip_local_port_range": "32768\t60999\n",
+	})
+
+	tchecktest.ExpectResult(t, tchecktest.Run(Sysctl("net.ipv4.ip_forward", "1", WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(Sysctl("net.ipv4.ip_forward", "0", WithRoot(root))), tcheck.StatusFailed, `net.ipv4.ip_forward is "1", expected "0"`)
+	tchecktest.ExpectResult(t, tchecktest.Run(Sysctl("net.ipv4.ip_local_port_range", "32768 60999", WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(Sysctl("net.ipv4.missing", "1", WithRoot(root))), tcheck.StatusFailed, "")
+}
+
+func TestSysctlLimits(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/sys/fs/inotify/max_user_watches": "8192\n",
+		"proc/sys/vm/swappiness":               "60\n",
+		"proc/sys/kernel/ostype":               "Linux\n",
+	})
+
+	tchecktest.ExpectResult(t, tchecktest.Run(SysctlMin("fs.inotify.max_user_watches", Limit{Fail: 8192}, WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(SysctlMin("fs.inotify.max_user_watches", Limit{Fail: 1024, Warn: 524288}, WithRoot(root))), tcheck.StatusWarning, "fs.inotify.max_user_watches is 8192, less than 524288")
+	tchecktest.ExpectResult(t, tchecktest.Run(SysctlMax("vm.swappiness", Limit{Fail: 100, Warn: 10}, WithRoot(root))), tcheck.StatusWarning, "vm.swappiness is 60, more than 10")
+	tchecktest.ExpectResult(t, tchecktest.Run(SysctlMax("vm.swappiness", Limit{Fail: 30}, WithRoot(root))), tcheck.StatusFailed, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(SysctlMin("kernel.ostype", Limit{}, WithRoot(root))), tcheck.StatusFailed, "not a number")
+}
+
+func TestKernelVersion(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/sys/kernel/osrelease": "5.15.0-91-generic\n"})
+
+	tests := []struct {
+		limit   VersionLimit
+		status  tcheck.CheckStatus
+		errText string
+	}{
+		{VersionLimit{}, tcheck.StatusCompleted, ""},
+		{VersionLimit{Fail: "4.19", Warn: "5.15"}, tcheck.StatusCompleted, ""},
+		{VersionLimit{Fail: "4.19", Warn: "6.1"}, tcheck.StatusWarning, "kernel 5.15.0-91-generic is older than 6.1"},
+		{VersionLimit{Fail: "5.15.1"}, tcheck.StatusFailed, "older than 5.15.1"},
+	}
+	for _, tt := range tests {
+		s := tchecktest.Run(KernelVersion(tt.limit, WithRoot(root)))
+		tchecktest.ExpectResult(t, s, tt.status, tt.errText)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"5.15.0-91-generic", "5.15", 0},
+		{"5.15.0", "5.15.1", -1},
+		{"6.1.0-13-amd64", "5.15", 1},
+		{"5.4", "5.10", -1},
+		{"4.19.0+", "4.19", 0},
+		{"6.8.0rc1", "6.8", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package syscheck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// ProcessRunning returns a check that a process with the given name is
+// running. The name is matched against the command name of each process, like
+// plain pgrep does. As the kernel truncates command names to 15 bytes, a
+// truncated name is replaced by the base name of the executable in cmdline,
+// if it starts with the truncated name, so longer names match as well.
+func ProcessRunning(name string, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Listing processes")
+		entries, err := os.ReadDir(c.path("proc"))
+		if err != nil {
+			return err
+		}
+
+		var pids []string
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, err := strconv.Atoi(entry.Name()); err != nil || !entry.IsDir() {
+				continue
+			}
+			if c.processName(entry.Name()) == name {
+				pids = append(pids, entry.Name())
+			}
+		}
+		if len(pids) == 0 {
+			return fmt.Errorf("no process named %s is running", name)
+		}
+
+		tcheck.LoggerOf(reporter).Infof("%s is running with PID %s", name, strings.Join(pids, ", "))
+		reporter.ReportSubProgress(100, fmt.Sprintf("%s is running (%d processes)", name, len(pids)))
+		return nil
+	}
+}
+
+// processName returns the name of the process pid, or "" if it has exited.
+// The command name in comm is truncated to 15 bytes, so longer names are
+// taken from the executable in cmdline.
+func (c *config) processName(pid string) string {
+	comm, err := c.readFile(filepath.Join("proc", pid, "comm"))
+	if err != nil {
+		return ""
+	}
+	if len(comm) < 15 {
+		return comm
+	}
+	cmdline, err := os.ReadFile(c.path(filepath.Join("proc", pid, "cmdline")))
+	if err != nil {
+		return comm
+	}
+	exe, _, _ := strings.Cut(string(cmdline), "\x00")
+	if base := filepath.Base(exe); strings.HasPrefix(base, comm) {
+		return base
+	}
+	return comm
+}
@@ -0,0 +1,36 @@
+package syscheck
+
+import (
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+func TestProcessRunning(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/1/comm":      "systemd\n",
+		"proc/1/cmdline":   "/sbin/init\x00splash\x00",
+		"proc/42/comm":     "nginx\n",
+		"proc/43/comm":     "nginx\n",
+		"proc/99/comm":     "very-long-daemo\n",
+		"proc/99/cmdline":  "/usr/bin/very-long-daemon-name\x00--flag\x00",
+		"proc/self/comm":   "nginx\n", // Not a PID
+		"proc/meminfo":     "MemTotal: 1 kB\n",
+		"proc/100/cmdline": "", // Exited while listing
+	})
+
+	s := tchecktest.Run(ProcessRunning("nginx", WithRoot(root)))
+	tchecktest.ExpectResult(t, s, tcheck.StatusCompleted, "")
+	if s.SubMessage != "nginx is running (2 processes)" {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+	if len(s.Logs) != 1 || s.Logs[0].Message != "nginx is running with PID 42, 43" {
+		t.Errorf("Unexpected log %v", s.Logs)
+	}
+
+	tchecktest.ExpectResult(t, tchecktest.Run(ProcessRunning("systemd", WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(ProcessRunning("very-long-daemon-name", WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(ProcessRunning("very-long-daemo", WithRoot(root))), tcheck.StatusFailed, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(ProcessRunning("init", WithRoot(root))), tcheck.StatusFailed, "no process named init is running")
+}
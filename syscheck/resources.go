@@ -0,0 +1,252 @@
+package syscheck
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// MemAvailable returns a check of the memory available for new processes,
+// in bytes. It fails below limit.Fail and warns below limit.Warn.
+func MemAvailable(limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading /proc/meminfo")
+		info, err := c.meminfo()
+		if err != nil {
+			return err
+		}
+		available, ok := info["MemAvailable"]
+		if !ok {
+			// Kernels before 3.14 do not estimate it
+			available = info["MemFree"] + info["Buffers"] + info["Cached"]
+		}
+		tcheck.LoggerOf(reporter).Infof("%s of %s memory available", formatBytes(available), formatBytes(info["MemTotal"]))
+
+		if err := limit.checkMin("available memory", available, formatBytes); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(100, formatBytes(available)+" memory available")
+		return nil
+	}
+}
+
+// meminfo returns the fields of /proc/meminfo, in bytes.
+func (c *config) meminfo() (map[string]float64, error) {
+	content, err := c.readFile("proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+	info := make(map[string]float64)
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		n, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("/proc/meminfo: invalid %s: %w", key, err)
+		}
+		if len(fields) > 1 && fields[1] == "kB" {
+			n *= 1024
+		}
+		info[key] = n
+	}
+	if _, ok := info["MemTotal"]; !ok {
+		return nil, errors.New("/proc/meminfo: no MemTotal")
+	}
+	return info, nil
+}
+
+// LoadAverage returns a check of the 1-minute load average per CPU. It fails
+// above limit.Fail and warns above limit.Warn, e.g. Limit{Fail: 2, Warn: 1}.
+func LoadAverage(limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading /proc/loadavg")
+		content, err := c.readFile("proc/loadavg")
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(content)
+		if len(fields) < 3 {
+			return fmt.Errorf("/proc/loadavg: unexpected content %q", content)
+		}
+		load, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return fmt.Errorf("/proc/loadavg: %w", err)
+		}
+		cpus, err := c.cpuCount()
+		if err != nil {
+			return err
+		}
+		perCPU := load / float64(cpus)
+		tcheck.LoggerOf(reporter).Infof("load average %s %s %s on %d CPUs", fields[0], fields[1], fields[2], cpus)
+
+		if err := limit.checkMax("load average per CPU", perCPU, formatLoad); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(100, "Load average per CPU "+formatLoad(perCPU))
+		return nil
+	}
+}
+
+// CPUCount returns a check of the number of online CPUs. It fails below
+// limit.Fail and warns below limit.Warn.
+func CPUCount(limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Counting CPUs")
+		cpus, err := c.cpuCount()
+		if err != nil {
+			return err
+		}
+		if err := limit.checkMin("number of CPUs", float64(cpus), formatCount); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(100, fmt.Sprintf("%d CPUs online", cpus))
+		return nil
+	}
+}
+
+// cpuCount returns the number of online CPUs from /sys, or from
+// /proc/cpuinfo if /sys is not available.
+func (c *config) cpuCount() (int, error) {
+	online, err := c.readFile("sys/devices/system/cpu/online")
+	if errors.Is(err, os.ErrNotExist) {
+		return c.cpuinfoCount()
+	}
+	if err != nil {
+		return 0, err
+	}
+	count, err := parseCPUList(online)
+	if err != nil {
+		return 0, fmt.Errorf("/sys/devices/system/cpu/online: %w", err)
+	}
+	return count, nil
+}
+
+// parseCPUList returns the number of CPUs in a list such as "0-3,6,8-9".
+func parseCPUList(list string) (int, error) {
+	count := 0
+	for _, part := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU list %q", list)
+		}
+		to := from
+		if isRange {
+			if to, err = strconv.Atoi(last); err != nil || to < from {
+				return 0, fmt.Errorf("invalid CPU list %q", list)
+			}
+		}
+		count += to - from + 1
+	}
+	return count, nil
+}
+
+// cpuinfoCount returns the number of processors listed in /proc/cpuinfo.
+func (c *config) cpuinfoCount() (int, error) {
+	f, err := os.Open(c.path("proc/cpuinfo"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	count := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, _, ok := strings.Cut(scanner.Text(), ":"); ok && strings.TrimSpace(key) == "processor" {
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, errors.New("/proc/cpuinfo: no processors")
+	}
+	return count, nil
+}
+
+// OpenFileLimit returns a check of the soft limit on open files of the
+// current process, as set by "ulimit -n". It fails below limit.Fail and
+// warns below limit.Warn.
+func OpenFileLimit(limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading /proc/self/limits")
+		content, err := c.readFile("proc/self/limits")
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(content, "\n") {
+			rest, ok := strings.CutPrefix(line, "Max open files")
+			if !ok {
+				continue
+			}
+			fields := strings.Fields(rest)
+			if len(fields) < 2 {
+				break
+			}
+			tcheck.LoggerOf(reporter).Infof("open files: soft limit %s, hard limit %s", fields[0], fields[1])
+			if fields[0] == "unlimited" {
+				reporter.ReportSubProgress(100, "Open files unlimited")
+				return nil
+			}
+			soft, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				return fmt.Errorf("/proc/self/limits: %w", err)
+			}
+			if err := limit.checkMin("open file limit", soft, formatCount); err != nil {
+				return err
+			}
+			reporter.ReportSubProgress(100, "Open file limit "+fields[0])
+			return nil
+		}
+		return errors.New("/proc/self/limits: no open file limit")
+	}
+}
+
+// FileHandles returns a check of the system-wide usage of file handles, in
+// percent of fs.file-max. It fails above limit.Fail and warns above limit.Warn.
+func FileHandles(limit Limit, opts ...Option) tcheck.CheckFuncContext {
+	c := newConfig(opts)
+	return func(ctx context.Context, reporter tcheck.SubProgressReporter) error {
+		reporter.ReportSubProgress(0, "Reading /proc/sys/fs/file-nr")
+		content, err := c.readFile("proc/sys/fs/file-nr")
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(content)
+		if len(fields) != 3 {
+			return fmt.Errorf("/proc/sys/fs/file-nr: unexpected content %q", content)
+		}
+		allocated, err1 := strconv.ParseFloat(fields[0], 64)
+		maxHandles, err2 := strconv.ParseFloat(fields[2], 64)
+		if err := errors.Join(err1, err2); err != nil {
+			return fmt.Errorf("/proc/sys/fs/file-nr: %w", err)
+		}
+		if maxHandles <= 0 {
+			return fmt.Errorf("/proc/sys/fs/file-nr: invalid maximum %s", fields[2])
+		}
+		usage := allocated / maxHandles * 100
+		tcheck.LoggerOf(reporter).Infof("%s of %s file handles allocated", fields[0], fields[2])
+
+		if err := limit.checkMax("file handle usage", usage, formatPercent); err != nil {
+			return err
+		}
+		reporter.ReportSubProgress(100, "File handle usage "+formatPercent(usage))
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package syscheck
+
+import (
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+const testMeminfo = `MemTotal:        8000000 kB
+MemFree:          500000 kB
+MemAvailable:    2000000 kB
+Buffers:          100000 kB
+Cached:          1000000 kB
+HugePages_Total:       0
+`
+
+func TestMemAvailable(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/meminfo": testMeminfo})
+	const available = 2000000 * 1024
+
+	tests := []struct {
+		name    string
+		limit   Limit
+		status  tcheck.CheckStatus
+		errText string
+	}{
+		{"enough", Limit{Fail: available / 2, Warn: available}, tcheck.StatusCompleted, ""},
+		{"low", Limit{Fail: available / 2, Warn: available * 2}, tcheck.StatusWarning, "available memory is 1.9 GiB, less than 3.8 GiB"},
+		{"too low", Limit{Fail: available * 2}, tcheck.StatusFailed, "less than 3.8 GiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tchecktest.ExpectResult(t, tchecktest.Run(MemAvailable(tt.limit, WithRoot(root))), tt.status, tt.errText)
+		})
+	}
+
+	s := tchecktest.Run(MemAvailable(Limit{}, WithRoot(root)))
+	if s.SubMessage != "1.9 GiB memory available" {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+}
+
+func TestMemAvailable_OldKernel(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/meminfo": "MemTotal: 8000000 kB\nMemFree: 1000 kB\nBuffers: 1000 kB\nCached: 2000 kB\n"})
+	s := tchecktest.Run(MemAvailable(Limit{Fail: 5000 * 1024}, WithRoot(root)))
+	tchecktest.ExpectResult(t, s, tcheck.StatusFailed, "available memory is 3.9 MiB")
+}
+
+func TestMemAvailable_Invalid(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/meminfo": "MemFree: 1000 kB\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(MemAvailable(Limit{}, WithRoot(root))), tcheck.StatusFailed, "no MemTotal")
+	tchecktest.ExpectResult(t, tchecktest.Run(MemAvailable(Limit{}, WithRoot(t.TempDir()))), tcheck.StatusFailed, "")
+}
+
+func TestLoadAverage(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/loadavg":                  "6.00 4.00 2.00 3/512 12345\n",
+		"sys/devices/system/cpu/online": "0-3\n",
+	})
+
+	// 6.00 on 4 CPUs is 1.5 per CPU
+	tchecktest.ExpectResult(t, tchecktest.Run(LoadAverage(Limit{Fail: 2, Warn: 1.5}, WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(LoadAverage(Limit{Fail: 2, Warn: 1}, WithRoot(root))), tcheck.StatusWarning, "load average per CPU is 1.50, more than 1.00")
+	tchecktest.ExpectResult(t, tchecktest.Run(LoadAverage(Limit{Fail: 1}, WithRoot(root))), tcheck.StatusFailed, "more than 1.00")
+}
+
+func TestCPUCount(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"sys/devices/system/cpu/online": "0-3,6,8-9\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(CPUCount(Limit{Fail: 7}, WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(CPUCount(Limit{Fail: 2, Warn: 8}, WithRoot(root))), tcheck.StatusWarning, "number of CPUs is 7, less than 8")
+	tchecktest.ExpectResult(t, tchecktest.Run(CPUCount(Limit{Fail: 8}, WithRoot(root))), tcheck.StatusFailed, "")
+
+	// Without /sys, processors are counted in /proc/cpuinfo
+	root = fakeRoot(t, map[string]string{"proc/cpuinfo": "processor\t: 0\nmodel name\t: Test\n\nprocessor\t: 1\nmodel name\t: Test\n"})
+	s := tchecktest.Run(CPUCount(Limit{}, WithRoot(root)))
+	tchecktest.ExpectResult(t, s, tcheck.StatusCompleted, "")
+	if s.SubMessage != "2 CPUs online" {
+		t.Errorf("Unexpected message %q", s.SubMessage)
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	for list, expected := range map[string]int{"0": 1, "0-7": 8, "0,2,4": 3, "0-1,4-5": 4} {
+		if got, err := parseCPUList(list); err != nil || got != expected {
+			t.Errorf("parseCPUList(%q) = %d, %v, expected %d", list, got, err, expected)
+		}
+	}
+	for _, list := range []string{"", "a", "3-1", "0-"} {
+		if _, err := parseCPUList(list); err == nil {
+			t.Errorf("Expected an error for %q", list)
+		}
+	}
+}
+
+const testLimits = `Limit                     Soft Limit           Hard Limit           Units
+Max cpu time              unlimited            unlimited            seconds
+Max open files            1024                 524288               files
+Max locked memory         8388608              8388608              bytes
+`
+
+func TestOpenFileLimit(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/self/limits": testLimits})
+	tchecktest.ExpectResult(t, tchecktest.Run(OpenFileLimit(Limit{Fail: 1024}, WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(OpenFileLimit(Limit{Fail: 512, Warn: 4096}, WithRoot(root))), tcheck.StatusWarning, "open file limit is 1024, less than 4096")
+	tchecktest.ExpectResult(t, tchecktest.Run(OpenFileLimit(Limit{Fail: 65536}, WithRoot(root))), tcheck.StatusFailed, "")
+
+	root = fakeRoot(t, map[string]string{"proc/self/limits": "Max open files            unlimited            unlimited            files\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(OpenFileLimit(Limit{Fail: 65536}, WithRoot(root))), tcheck.StatusCompleted, "")
+
+	root = fakeRoot(t, map[string]string{"proc/self/limits": "Max cpu time              unlimited            unlimited            seconds\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(OpenFileLimit(Limit{}, WithRoot(root))), tcheck.StatusFailed, "no open file limit")
+}
+
+func TestFileHandles(t *testing.T) {
+	root := fakeRoot(t, map[string]string{"proc/sys/fs/file-nr": "800\t0\t1000\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(FileHandles(Limit{Fail: 90, Warn: 80}, WithRoot(root))), tcheck.StatusCompleted, "")
+	tchecktest.ExpectResult(t, tchecktest.Run(FileHandles(Limit{Fail: 90, Warn: 50}, WithRoot(root))), tcheck.StatusWarning, "file handle usage is 80.0%, more than 50.0%")
+	tchecktest.ExpectResult(t, tchecktest.Run(FileHandles(Limit{Fail: 75}, WithRoot(root))), tcheck.StatusFailed, "")
+
+	root = fakeRoot(t, map[string]string{"proc/sys/fs/file-nr": "800 0\n"})
+	tchecktest.ExpectResult(t, tchecktest.Run(FileHandles(Limit{}, WithRoot(root))), tcheck.StatusFailed, "unexpected content")
+}
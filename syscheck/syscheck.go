@@ -0,0 +1,115 @@
+// Package syscheck provides ready-made Linux system resource checks for
+// tcheck, reading /proc and /sys: available memory, load average, CPU count,
+// open-file limits, sysctl values, kernel version and running processes.
+//
+// Thresholds are given as a Limit, which makes a check pass, warn or fail.
+// The constructors accept WithRoot to read from another root directory, e.g.
+// the host's filesystem mounted into a container, or a fake one in tests.
+//
+//	manager.AddCheckContext("Memory", syscheck.MemAvailable(syscheck.Limit{Fail: 512 << 20, Warn: 2 << 30}))
+package syscheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+)
+
+// Limit is a pair of thresholds for a value. Whether values below or above
+// them are bad depends on the check. Zero thresholds are not checked.
+type Limit struct {
+	Fail float64 // The check fails beyond this value
+	Warn float64 // The check warns beyond this value
+}
+
+// checkMin fails if value is below l.Fail and warns if it is below l.Warn.
+// what describes the value and format formats values for the error.
+func (l Limit) checkMin(what string, value float64, format func(float64) string) error {
+	switch {
+	case l.Fail != 0 && value < l.Fail:
+		return fmt.Errorf("%s is %s, less than %s", what, format(value), format(l.Fail))
+	case l.Warn != 0 && value < l.Warn:
+		return tcheck.Warnf("%s is %s, less than %s", what, format(value), format(l.Warn))
+	}
+	return nil
+}
+
+// checkMax fails if value is above l.Fail and warns if it is above l.Warn.
+// what describes the value and format formats values for the error.
+func (l Limit) checkMax(what string, value float64, format func(float64) string) error {
+	switch {
+	case l.Fail != 0 && value > l.Fail:
+		return fmt.Errorf("%s is %s, more than %s", what, format(value), format(l.Fail))
+	case l.Warn != 0 && value > l.Warn:
+		return tcheck.Warnf("%s is %s, more than %s", what, format(value), format(l.Warn))
+	}
+	return nil
+}
+
+// Option configures the checks of this package.
+type Option func(*config)
+
+// WithRoot makes a check read /proc and /sys below root instead of "/".
+func WithRoot(root string) Option {
+	return func(c *config) {
+		c.root = root
+	}
+}
+
+type config struct {
+	root string
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{root: "/"}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// path returns the path of the given /proc or /sys file below the root.
+func (c *config) path(name string) string {
+	return filepath.Join(c.root, name)
+}
+
+// readFile returns the trimmed content of the given /proc or /sys file.
+func (c *config) readFile(name string) (string, error) {
+	data, err := os.ReadFile(c.path(name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// formatCount formats a count, such as a number of CPUs.
+func formatCount(v float64) string {
+	return fmt.Sprintf("%.0f", v)
+}
+
+// formatLoad formats a load average.
+func formatLoad(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// formatPercent formats a percentage.
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v)
+}
+
+// formatBytes formats v bytes with a binary unit, e.g. "1.5 GiB".
+func formatBytes(v float64) string {
+	const unit = 1024
+	if v < unit {
+		return fmt.Sprintf("%.0f B", v)
+	}
+	div, exp := float64(unit), 0
+	for m := v / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", v/div, "KMGTPE"[exp])
+}
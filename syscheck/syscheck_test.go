@@ -0,0 +1,91 @@
+package syscheck
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	tcheck "github.com/Golevka2001/go-tcheck"
+	"github.com/Golevka2001/go-tcheck/internal/tchecktest"
+)
+
+// fakeRoot returns a root directory containing the given files, keyed by
+// their path relative to the root, e.g. "proc/loadavg".
+func fakeRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLimit(t *testing.T) {
+	limit := Limit{Fail: 10, Warn: 20}
+	tests := []struct {
+		name     string
+		check    func(string, float64, func(float64) string) error
+		value    float64
+		expected string // "pass", "warn" or "fail"
+	}{
+		{"min above", limit.checkMin, 30, "pass"},
+		{"min at warn", limit.checkMin, 20, "pass"},
+		{"min below warn", limit.checkMin, 15, "warn"},
+		{"min below fail", limit.checkMin, 5, "fail"},
+		{"max below", limit.checkMax, 5, "pass"},
+		{"max above fail", limit.checkMax, 15, "fail"},
+	}
+	for _, tt := range tests {
+		err := tt.check("value", tt.value, formatCount)
+		got := "pass"
+		switch {
+		case errors.As(err, new(*tcheck.WarningError)):
+			got = "warn"
+		case err != nil:
+			got = "fail"
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %s, got %s (%v)", tt.name, tt.expected, got, err)
+		}
+	}
+
+	// Zero thresholds are not checked
+	if err := (Limit{}).checkMin("value", -1, formatCount); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := (Limit{Warn: 1}).checkMax("value", 2, formatCount); !errors.As(err, new(*tcheck.WarningError)) {
+		t.Errorf("Expected a warning, got %v", err)
+	}
+	if err := (Limit{Fail: 1}).checkMax("value", 2, formatCount); err == nil || err.Error() != "value is 2, more than 1" {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+// TestLocalSystem runs the checks against the real /proc and /sys.
+func TestLocalSystem(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("requires Linux")
+	}
+	for name, fn := range map[string]tcheck.CheckFuncContext{
+		"memory":  MemAvailable(Limit{}),
+		"load":    LoadAverage(Limit{}),
+		"cpus":    CPUCount(Limit{Fail: 1}),
+		"limits":  OpenFileLimit(Limit{}),
+		"handles": FileHandles(Limit{}),
+		"kernel":  KernelVersion(VersionLimit{Fail: "2.6"}),
+		"sysctl":  SysctlMin("kernel.pid_max", Limit{Fail: 1}),
+		"process": ProcessRunning(filepath.Base(os.Args[0])),
+	} {
+		if s := tchecktest.Run(fn); s.Status != tcheck.StatusCompleted {
+			t.Errorf("%s: expected StatusCompleted, got %v: %v", name, s.Status, s.Error)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package tcheck
+
+import "fmt"
+
+// Severity describes how much the outcome of a check matters for the verdict.
+type Severity int
+
+const (
+	SeverityRequired      Severity = iota // A failure fails the verdict, the default
+	SeverityOptional                      // A failure only results in a warning verdict
+	SeverityInformational                 // The outcome never affects the verdict
+)
+
+// String returns a human-readable name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityRequired:
+		return "required"
+	case SeverityOptional:
+		return "optional"
+	case SeverityInformational:
+		return "informational"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// WithSeverity sets the severity of the check. Checks are SeverityRequired by default.
+func WithSeverity(severity Severity) CheckOption {
+	return func(ci *CheckItem) {
+		ci.severity = severity
+	}
+}
+
+// Verdict is the final outcome of all checks of a manager.
+type Verdict int
+
+const (
+	VerdictPass Verdict = iota // All checks that matter passed
+	VerdictWarn                // No check that matters failed, but some warned
+	VerdictFail                // A check that matters failed or did not finish
+)
+
+// String returns a human-readable name of the verdict.
+func (v Verdict) String() string {
+	switch v {
+	case VerdictPass:
+		return "pass"
+	case VerdictWarn:
+		return "warn"
+	case VerdictFail:
+		return "fail"
+	default:
+		return fmt.Sprintf("Verdict(%d)", int(v))
+	}
+}
+
+// ExitCode returns the conventional process exit code of the verdict:
+// 0 for VerdictPass and VerdictWarn, 1 for VerdictFail.
+func (v Verdict) ExitCode() int {
+	if v == VerdictFail {
+		return 1
+	}
+	return 0
+}
+
+// VerdictPolicy configures how the outcomes of checks are combined into a Verdict.
+type VerdictPolicy struct {
+	// Strict makes warnings, and failures of optional checks, fail the verdict.
+	Strict bool
+}
+
+// WithVerdictPolicy sets the policy used by CheckManager.Verdict.
+func WithVerdictPolicy(policy VerdictPolicy) ManagerOption {
+	return func(cm *CheckManager) {
+		cm.verdictPolicy = policy
+	}
+}
+
+// verdict returns the contribution of a single check to the verdict.
+func (p VerdictPolicy) verdict(severity Severity, status CheckStatus) Verdict {
+	var v Verdict
+	switch status {
+	case StatusCompleted, StatusSkipped:
+		return VerdictPass
+	case StatusWarning:
+		v = VerdictWarn
+	default:
+		// Failed, or never finished
+		v = VerdictFail
+	}
+
+	switch severity {
+	case SeverityInformational:
+		return VerdictPass
+	case SeverityOptional:
+		v = min(v, VerdictWarn)
+	}
+	if v == VerdictWarn && p.Strict {
+		return VerdictFail
+	}
+	return v
+}
+
+// Verdict combines the outcomes of all checks into a final verdict according
+// to the policy set with WithVerdictPolicy:
+//   - failed, cancelled, aborted or unfinished required checks fail the verdict;
+//   - warnings and failures of optional checks result in VerdictWarn, or fail
+//     the verdict in strict mode;
+//   - informational checks, and completed or skipped checks, do not matter.
+func (cm *CheckManager) Verdict() Verdict {
+	verdict := VerdictPass
+	for _, s := range cm.Snapshot() {
+		verdict = max(verdict, cm.verdictPolicy.verdict(s.Severity, s.Status))
+	}
+	return verdict
+}
+
+// ExitCode returns the exit code of the current verdict, see Verdict.ExitCode.
+func (cm *CheckManager) ExitCode() int {
+	return cm.Verdict().ExitCode()
+}
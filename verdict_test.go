@@ -0,0 +1,70 @@
+package tcheck
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerdict(t *testing.T) {
+	fail := func(SubProgressReporter) error { return errors.New("broken") }
+	warn := func(SubProgressReporter) error { return Warnf("degraded") }
+
+	tests := []struct {
+		name     string
+		policy   VerdictPolicy
+		add      func(cm *CheckManager)
+		expected Verdict
+	}{
+		{"all passed", VerdictPolicy{}, func(cm *CheckManager) {
+			cm.AddCheck("ok", testFunc)
+			cm.AddCheck("skipped", func(SubProgressReporter) error { return Skip("n/a") })
+		}, VerdictPass},
+		{"required failed", VerdictPolicy{}, func(cm *CheckManager) {
+			cm.AddCheck("ok", testFunc)
+			cm.AddCheck("fail", fail)
+		}, VerdictFail},
+		{"warning", VerdictPolicy{}, func(cm *CheckManager) {
+			cm.AddCheck("warn", warn)
+		}, VerdictWarn},
+		{"warning in strict mode", VerdictPolicy{Strict: true}, func(cm *CheckManager) {
+			cm.AddCheck("warn", warn)
+		}, VerdictFail},
+		{"optional failed", VerdictPolicy{}, func(cm *CheckManager) {
+			cm.AddCheck("fail", fail, WithSeverity(SeverityOptional))
+		}, VerdictWarn},
+		{"optional failed in strict mode", VerdictPolicy{Strict: true}, func(cm *CheckManager) {
+			cm.AddCheck("fail", fail, WithSeverity(SeverityOptional))
+		}, VerdictFail},
+		{"informational failed", VerdictPolicy{Strict: true}, func(cm *CheckManager) {
+			cm.AddCheck("fail", fail, WithSeverity(SeverityInformational))
+			cm.AddCheck("warn", warn, WithSeverity(SeverityInformational))
+		}, VerdictPass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewCheckManager(nil, 2, WithVerdictPolicy(tt.policy))
+			tt.add(cm)
+			cm.RunAllChecks().Wait()
+
+			if verdict := cm.Verdict(); verdict != tt.expected {
+				t.Errorf("Expected verdict %v, got %v", tt.expected, verdict)
+			}
+			if code, expected := cm.ExitCode(), tt.expected.ExitCode(); code != expected {
+				t.Errorf("Expected exit code %d, got %d", expected, code)
+			}
+		})
+	}
+}
+
+func TestVerdict_Unfinished(t *testing.T) {
+	cm := NewCheckManager(nil, 1)
+	cm.AddCheck("pending", testFunc)
+
+	if verdict := cm.Verdict(); verdict != VerdictFail {
+		t.Errorf("Expected pending required checks to fail the verdict, got %v", verdict)
+	}
+	if code := cm.ExitCode(); code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+}